@@ -0,0 +1,61 @@
+// Command admin runs maintenance tasks that must not be reachable over HTTP.
+//
+//	admin grant-admin <email>
+//	admin revoke-admin <email>
+//	admin rotate-keys   rewrap note data keys under the current master key
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/config"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/database"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	cfg := config.Load()
+	if err := database.Connect(cfg); err != nil {
+		log.Fatalf("Database connection failed: %v", err)
+	}
+	authService := services.NewAuthService(database.DB, cfg, nil)
+
+	switch os.Args[1] {
+	case "grant-admin", "revoke-admin":
+		if len(os.Args) != 3 {
+			usage()
+		}
+		role := models.RoleAdmin
+		if os.Args[1] == "revoke-admin" {
+			role = models.RoleUser
+		}
+		if err := authService.SetRole(os.Args[2], role); err != nil {
+			log.Fatalf("Failed to set role: %v", err)
+		}
+		log.Printf("%s is now %s", os.Args[2], role)
+	case "rotate-keys":
+		noteCipher, err := services.NewNoteCipher(database.DB, cfg)
+		if err != nil {
+			log.Fatalf("Note encryption setup failed: %v", err)
+		}
+		rotated, err := noteCipher.RotateMasterKey()
+		if err != nil {
+			log.Fatalf("Key rotation failed after %d keys: %v", rotated, err)
+		}
+		log.Printf("Rewrapped %d note keys", rotated)
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: admin grant-admin <email> | revoke-admin <email> | rotate-keys")
+	os.Exit(2)
+}
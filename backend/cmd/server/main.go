@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
@@ -10,6 +11,7 @@ import (
 	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/database"
 	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/handlers"
 	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/middleware"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
 	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/routes"
 	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
 	"github.com/gofiber/fiber/v2"
@@ -20,6 +22,8 @@ import (
 	"time"
 )
 
+const defaultBodyLimit = 4 * 1024 * 1024 // 4MB
+
 func main() {
 	cfg := config.Load()
 
@@ -29,6 +33,9 @@ func main() {
 	if cfg.DBPassword == "" {
 		log.Fatal("DB_PASSWORD environment variable is required")
 	}
+	if err := models.SetActiveScoreVersion(cfg.FeelScoreVersion); err != nil {
+		log.Fatalf("Invalid FEEL_SCORE_VERSION: %v", err)
+	}
 
 	// Database
 	if err := database.Connect(cfg); err != nil {
@@ -39,10 +46,53 @@ func main() {
 	}
 
 	// Services
-	authService := services.NewAuthService(database.DB, cfg)
+	objectStorage, err := services.NewObjectStorage(cfg)
+	if err != nil {
+		log.Fatalf("Object storage setup failed: %v", err)
+	}
+	photoService := services.NewPhotoService(database.DB, objectStorage, cfg)
+	authService := services.NewAuthService(database.DB, cfg, photoService)
 	subscriptionService := services.NewSubscriptionService(database.DB)
 	moderationService := services.NewModerationService(database.DB)
-	feelService := services.NewFeelService(database.DB)
+	noteCipher, err := services.NewNoteCipher(database.DB, cfg)
+	if err != nil {
+		log.Fatalf("Note encryption setup failed: %v", err)
+	}
+	if _, err := noteCipher.BackfillPlaintextNotes(); err != nil {
+		log.Fatalf("Note encryption backfill failed: %v", err)
+	}
+	wellbeingService, err := services.NewWellbeingService(database.DB, cfg)
+	if err != nil {
+		log.Fatalf("Wellbeing resources setup failed: %v", err)
+	}
+	goalService := services.NewGoalService(database.DB)
+	notifier, err := services.NewNotifier(cfg.Notifier)
+	if err != nil {
+		log.Fatalf("Notifier setup failed: %v", err)
+	}
+	reminderService := services.NewReminderService(database.DB, notifier)
+	questionnaireService, err := services.NewQuestionnaireService(database.DB, cfg.QuestionnairesFile, wellbeingService, notifier)
+	if err != nil {
+		log.Fatalf("Questionnaire setup failed: %v", err)
+	}
+	feelService := services.NewFeelService(database.DB, noteCipher, wellbeingService, goalService, photoService)
+	searchService := services.NewSearchService(database.DB, noteCipher)
+	insightsService := services.NewInsightsService(database.DB)
+	tagService := services.NewTagService(database.DB)
+	recapService := services.NewRecapService(database.DB)
+	wrappedService := services.NewWrappedService(database.DB)
+	forecastService := services.NewForecastService(database.DB)
+	moodImportService := services.NewMoodImportService(database.DB, noteCipher, feelService)
+	exportService := services.NewExportService(database.DB, noteCipher, cfg.PublicBaseURL)
+	promptService := services.NewPromptService(database.DB)
+	gratitudeService := services.NewGratitudeService(database.DB, noteCipher, moderationService)
+	mindfulnessService := services.NewMindfulnessService(database.DB)
+	shareService := services.NewShareService(database.DB, feelService, insightsService, cfg.PublicBaseURL)
+	healthImportService, err := services.NewHealthImportService(database.DB, cfg.ImportDir)
+	if err != nil {
+		log.Fatalf("Health import setup failed: %v", err)
+	}
+	idempotencyService := services.NewIdempotencyService(database.DB, noteCipher, cfg.IdempotencyTTL)
 
 	// Handlers
 	authHandler := handlers.NewAuthHandler(authService)
@@ -50,11 +100,33 @@ func main() {
 	webhookHandler := handlers.NewWebhookHandler(subscriptionService, cfg)
 	moderationHandler := handlers.NewModerationHandler(moderationService)
 	feelHandler := handlers.NewFeelHandler(feelService)
+	insightsHandler := handlers.NewInsightsHandler(insightsService)
+	tagHandler := handlers.NewTagHandler(tagService)
+	searchHandler := handlers.NewSearchHandler(searchService)
+	recapHandler := handlers.NewRecapHandler(recapService)
+	wrappedHandler := handlers.NewWrappedHandler(wrappedService)
+	wellbeingHandler := handlers.NewWellbeingHandler(wellbeingService)
+	goalHandler := handlers.NewGoalHandler(goalService)
+	reminderHandler := handlers.NewReminderHandler(reminderService)
+	forecastHandler := handlers.NewForecastHandler(forecastService)
+	healthImportHandler := handlers.NewHealthImportHandler(healthImportService)
+	moodImportHandler := handlers.NewMoodImportHandler(moodImportService)
+	exportHandler := handlers.NewExportHandler(exportService)
+	shareHandler := handlers.NewShareHandler(shareService)
+	questionnaireHandler := handlers.NewQuestionnaireHandler(questionnaireService)
+	promptHandler := handlers.NewPromptHandler(promptService)
+	photoHandler := handlers.NewPhotoHandler(photoService, objectStorage)
+	gratitudeHandler := handlers.NewGratitudeHandler(gratitudeService)
+	mindfulnessHandler := handlers.NewMindfulnessHandler(mindfulnessService)
 
 	// Fiber app
+	// Bodies are streamed so large health exports are never held in memory;
+	// middleware.BodyLimit enforces the 4MB limit everywhere else
 	app := fiber.New(fiber.Config{
-		BodyLimit:    4 * 1024 * 1024, // 4MB
-		ErrorHandler: customErrorHandler,
+		BodyLimit:                    defaultBodyLimit,
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
+		ErrorHandler:                 customErrorHandler,
 	})
 
 	// Global middleware
@@ -64,6 +136,10 @@ func main() {
 		Format: "${time} | ${status} | ${latency} | ${ip} | ${method} | ${path}\n",
 	}))
 	app.Use(middleware.CORS(cfg))
+	app.Use(middleware.BodyLimit(defaultBodyLimit, map[string]int{
+		"/api/imports/health":   cfg.HealthImportMaxMB * 1024 * 1024,
+		"/api/feels/:id/photos": cfg.PhotoMaxMB * 1024 * 1024,
+	}))
 
 	// Rate limiter on auth endpoints
 	authLimiter := limiter.New(limiter.Config{
@@ -73,8 +149,25 @@ func main() {
 	})
 	app.Use("/api/auth", authLimiter)
 
+	// Rate limiter on share link views
+	sharedLimiter := limiter.New(limiter.Config{
+		Max:               60,
+		Expiration:        1 * time.Minute,
+		LimiterMiddleware: limiter.SlidingWindow{},
+	})
+	app.Use("/api/shared", sharedLimiter)
+
 	// Routes
-	routes.Setup(app, cfg, authHandler, healthHandler, webhookHandler, moderationHandler, feelHandler)
+	routes.Setup(app, cfg, authService, authHandler, healthHandler, webhookHandler, moderationHandler, feelHandler, insightsHandler, tagHandler, searchHandler, recapHandler, wrappedHandler, wellbeingHandler, goalHandler, reminderHandler, forecastHandler, healthImportHandler, moodImportHandler, exportHandler, shareHandler, questionnaireHandler, promptHandler, photoHandler, gratitudeHandler, mindfulnessHandler, idempotencyService)
+
+	// Background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	go recapService.RunScheduler(jobsCtx, time.Hour)
+	go wrappedService.RunScheduler(jobsCtx, 24*time.Hour)
+	go reminderService.RunScheduler(jobsCtx, time.Minute)
+	go questionnaireService.RunScheduler(jobsCtx, 15*time.Minute)
+	go idempotencyService.RunScheduler(jobsCtx, time.Hour)
+	go healthImportService.RunWorker(jobsCtx, 30*time.Second)
 
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
@@ -90,6 +183,7 @@ func main() {
 
 	<-quit
 	log.Println("Shutting down server...")
+	stopJobs()
 	if err := app.Shutdown(); err != nil {
 		log.Fatalf("Server shutdown error: %v", err)
 	}
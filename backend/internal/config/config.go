@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"time"
 )
 
@@ -21,6 +22,43 @@ type Config struct {
 
 	Port        string
 	CORSOrigins string
+
+	FeelScoreVersion int
+
+	NoteMasterKey         string // base64-encoded 32-byte AES key
+	NoteMasterKeyFile     string // Alternative to NoteMasterKey: file containing the base64 key
+	NoteMasterKeyID       string
+	NoteRetiredMasterKeys string // Comma-separated "id:base64key" pairs kept for unwrapping during rotation
+
+	LowMoodThreshold       int           // Feel scores below this count as low
+	LowMoodDays            int           // Consecutive low days before a sustained-low alert
+	MoodDropPoints         int           // Drop from the recent average that counts as sharp
+	MoodVolatilityStdDev   int           // Standard deviation of recent scores that counts as volatile
+	WellbeingCooldown      time.Duration // Minimum time between alerts of the same kind
+	WellbeingResourcesFile string        // Optional JSON directory of tips and helplines by locale
+	QuestionnairesFile     string        // Optional JSON array of extra or replacement questionnaire definitions
+
+	Notifier string // Notification backend: log
+
+	IdempotencyTTL time.Duration // How long responses are kept for Idempotency-Key retries
+
+	HealthImportMaxMB int    // Upload limit for health exports
+	ImportDir         string // Where uploads wait to be parsed; shared storage with several instances
+
+	PublicBaseURL string // Scheme and host clients reach the API on, used for calendar feed links
+
+	StorageBackend    string        // Photo storage: local or s3
+	StorageDir        string        // Local backend directory, required for local storage
+	MediaSigningKey   string        // HMAC key for local media URLs, required for local storage
+	MediaURLTTL       time.Duration // How long signed photo URLs stay valid
+	S3Endpoint        string        // e.g. https://s3.eu-west-1.amazonaws.com or http://localhost:9000 for MinIO
+	S3Region          string
+	S3Bucket          string
+	S3AccessKey       string
+	S3SecretKey       string
+	S3PathStyle       bool // Bucket in the path instead of the host name; needed for MinIO
+	PhotoMaxMB        int  // Upload limit per photo
+	MaxPhotosPerCheck int
 }
 
 func Load() *Config {
@@ -40,6 +78,43 @@ func Load() *Config {
 
 		Port:        getEnv("PORT", "8080"),
 		CORSOrigins: getEnv("CORS_ORIGINS", "*"),
+
+		FeelScoreVersion: parseInt(getEnv("FEEL_SCORE_VERSION", "1"), 1),
+
+		NoteMasterKey:         getEnv("NOTE_MASTER_KEY", ""),
+		NoteMasterKeyFile:     getEnv("NOTE_MASTER_KEY_FILE", ""),
+		NoteMasterKeyID:       getEnv("NOTE_MASTER_KEY_ID", "v1"),
+		NoteRetiredMasterKeys: getEnv("NOTE_RETIRED_MASTER_KEYS", ""),
+
+		LowMoodThreshold:       parseInt(getEnv("LOW_MOOD_THRESHOLD", "40"), 40),
+		LowMoodDays:            parseInt(getEnv("LOW_MOOD_DAYS", "3"), 3),
+		MoodDropPoints:         parseInt(getEnv("MOOD_DROP_POINTS", "30"), 30),
+		MoodVolatilityStdDev:   parseInt(getEnv("MOOD_VOLATILITY_STDDEV", "25"), 25),
+		WellbeingCooldown:      parseDuration(getEnv("WELLBEING_COOLDOWN", "72h")),
+		WellbeingResourcesFile: getEnv("WELLBEING_RESOURCES_FILE", ""),
+		QuestionnairesFile:     getEnv("QUESTIONNAIRES_FILE", ""),
+
+		Notifier: getEnv("NOTIFIER", "log"),
+
+		IdempotencyTTL: parseDuration(getEnv("IDEMPOTENCY_TTL", "24h")),
+
+		HealthImportMaxMB: parseInt(getEnv("HEALTH_IMPORT_MAX_MB", "512"), 512),
+		ImportDir:         getEnv("IMPORT_DIR", ""),
+
+		PublicBaseURL: getEnv("PUBLIC_BASE_URL", ""),
+
+		StorageBackend:    getEnv("STORAGE_BACKEND", "local"),
+		StorageDir:        getEnv("STORAGE_DIR", ""),
+		MediaSigningKey:   getEnv("MEDIA_SIGNING_KEY", ""),
+		MediaURLTTL:       parseDuration(getEnv("MEDIA_URL_TTL", "15m")),
+		S3Endpoint:        getEnv("S3_ENDPOINT", ""),
+		S3Region:          getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:          getEnv("S3_BUCKET", ""),
+		S3AccessKey:       getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:       getEnv("S3_SECRET_KEY", ""),
+		S3PathStyle:       getEnv("S3_PATH_STYLE", "false") == "true",
+		PhotoMaxMB:        parseInt(getEnv("PHOTO_MAX_MB", "10"), 10),
+		MaxPhotosPerCheck: parseInt(getEnv("MAX_PHOTOS_PER_CHECK", "4"), 4),
 	}
 }
 
@@ -67,3 +142,11 @@ func parseDuration(s string) time.Duration {
 	}
 	return d
 }
+
+func parseInt(s string, fallback int) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
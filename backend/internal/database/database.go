@@ -42,18 +42,122 @@ func Migrate() error {
 		&models.Report{},
 		&models.Block{},
 		&models.FeelCheck{},
+		&models.FeelCheckPhoto{},
 		&models.FeelStreak{},
 		&models.FeelFriend{},
 		&models.GoodVibe{},
+		&models.Tag{},
+		&models.UserKey{},
+		&models.FeelCheckEmotion{},
+		&models.BadgeUnlock{},
+		&models.Recap{},
+		&models.WrappedSummary{},
+		&models.WellbeingAlert{},
+		&models.Goal{},
+		&models.GoalCompletion{},
+		&models.ReminderSetting{},
+		&models.ReminderDelivery{},
+		&models.IdempotencyKey{},
+		&models.MoodForecast{},
+		&models.HealthImport{},
+		&models.DailyMetric{},
+		&models.CalendarFeed{},
+		&models.ShareGrant{},
+		&models.ShareAccess{},
+		&models.QuestionnaireResult{},
+		&models.QuestionnaireSchedule{},
+		&models.ReflectionPrompt{},
+		&models.DailyPrompt{},
+		&models.GratitudeEntry{},
+		&models.MindfulnessSession{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	// Every insert or update of a check-in gets a new sync version for delta sync.
+	// Writers for the same user take versions one transaction at a time (the
+	// advisory lock is held until commit), so a user's versions commit in order
+	// and a delta never returns a version ahead of one still in flight.
+	syncStatements := []string{
+		`CREATE SEQUENCE IF NOT EXISTS feel_check_sync_seq`,
+		`CREATE OR REPLACE FUNCTION feel_checks_bump_sync_version() RETURNS trigger AS $$
+		BEGIN
+			PERFORM pg_advisory_xact_lock(hashtext('feel_check_sync'), hashtext(NEW.user_id::text));
+			NEW.sync_version := nextval('feel_check_sync_seq');
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql`,
+		`DROP TRIGGER IF EXISTS trg_feel_checks_sync_version ON feel_checks`,
+		// Rows written before the trigger existed get distinct versions so deltas can page
+		// through them. Runs without the trigger so it takes no per-user locks.
+		`UPDATE feel_checks SET sync_version = nextval('feel_check_sync_seq') WHERE sync_version = 0`,
+		`CREATE TRIGGER trg_feel_checks_sync_version BEFORE INSERT OR UPDATE ON feel_checks
+		FOR EACH ROW EXECUTE FUNCTION feel_checks_bump_sync_version()`,
+	}
+	for _, stmt := range syncStatements {
+		if err := DB.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to set up check-in sync versions: %w", err)
+		}
+	}
+
+	if err := seedDefaultTags(); err != nil {
+		return fmt.Errorf("failed to seed default tags: %w", err)
+	}
+
+	if err := seedDefaultPrompts(); err != nil {
+		return fmt.Errorf("failed to seed reflection prompts: %w", err)
+	}
+
 	log.Println("Database migrations completed")
 	return nil
 }
 
+// seedDefaultTags inserts any missing built-in tags
+func seedDefaultTags() error {
+	for _, tag := range models.DefaultTags {
+		var count int64
+		if err := DB.Model(&models.Tag{}).Where("is_default = ? AND name = ?", true, tag.Name).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		tag.IsDefault = true
+		if err := DB.Create(&tag).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedDefaultPrompts inserts any missing built-in reflection prompts. Prompts
+// an admin deleted stay deleted.
+func seedDefaultPrompts() error {
+	for _, prompt := range models.DefaultPrompts {
+		var count int64
+		if err := DB.Unscoped().Model(&models.ReflectionPrompt{}).Where("key = ?", *prompt.Key).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		if prompt.MinScore == 0 {
+			prompt.MinScore = 1
+		}
+		if prompt.MaxScore == 0 {
+			prompt.MaxScore = 100
+		}
+		prompt.Active = true
+		if err := DB.Create(&prompt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func Ping() error {
 	sqlDB, err := DB.DB()
 	if err != nil {
@@ -26,6 +26,10 @@ type AuthResponse struct {
 	User         UserResponse `json:"user"`
 }
 
+type UpdateTimezoneRequest struct {
+	Timezone string `json:"timezone"` // IANA name, e.g. Europe/Istanbul
+}
+
 type UserResponse struct {
 	ID    uuid.UUID `json:"id"`
 	Email string    `json:"email"`
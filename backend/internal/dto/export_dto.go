@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+// FeelExportQuery represents query parameters for GET /api/feels/export
+type FeelExportQuery struct {
+	Format string `query:"format"` // csv (default) or jsonl
+	From   string `query:"from"`   // YYYY-MM-DD, inclusive
+	To     string `query:"to"`     // YYYY-MM-DD, inclusive
+}
+
+// FeelExportRow is one check-in in a CSV or JSON Lines export
+type FeelExportRow struct {
+	ID          string     `json:"id"`
+	Date        string     `json:"date"`
+	MoodScore   int        `json:"mood_score"`
+	EnergyScore int        `json:"energy_score"`
+	FeelScore   int        `json:"feel_score"`
+	MoodEmoji   string     `json:"mood_emoji"`
+	Emotions    []string   `json:"emotions"`
+	Tags        []string   `json:"tags"`
+	Note        string     `json:"note"`
+	CreatedAt   time.Time  `json:"created_at"`
+	EditedAt    *time.Time `json:"edited_at"`
+}
+
+// CalendarFeedResponse represents a newly issued calendar feed URL. The token is
+// only shown once; issuing a new one revokes the old URL.
+type CalendarFeedResponse struct {
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
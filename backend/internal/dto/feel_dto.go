@@ -1,11 +1,24 @@
 package dto
 
+import "github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+
 // CreateFeelCheckRequest represents a request to create a feel check-in
 type CreateFeelCheckRequest struct {
-	MoodScore   int    `json:"mood_score" validate:"required,min=1,max=100"`
-	EnergyScore int    `json:"energy_score" validate:"required,min=1,max=100"`
-	MoodEmoji   string `json:"mood_emoji"`
-	Note        string `json:"note"`
+	MoodScore   int      `json:"mood_score" validate:"required,min=1,max=100"`
+	EnergyScore int      `json:"energy_score" validate:"required,min=1,max=100"`
+	MoodEmoji   string   `json:"mood_emoji"`
+	Note        string   `json:"note"`
+	TagIDs      []string `json:"tag_ids"`
+	EmotionIDs  []string `json:"emotion_ids"` // Catalog emotion IDs, see GET /api/feels/emotions
+	PromptID    string   `json:"prompt_id"`   // Reflection prompt the note answers, see GET /api/feels/prompt
+}
+
+// CreateFeelCheckResponse represents a just-created check-in. WellbeingAlert and
+// Resources are set when the check-in raised a wellbeing alert.
+type CreateFeelCheckResponse struct {
+	*models.FeelCheck
+	WellbeingAlert *models.WellbeingAlert `json:"wellbeing_alert,omitempty"`
+	Resources      []WellbeingResource    `json:"resources,omitempty"`
 }
 
 // SendGoodVibeRequest represents a request to send good vibes
@@ -35,3 +48,81 @@ type FeelStatsResponse struct {
 	AverageScore   float64  `json:"average_score"`
 	UnlockedBadges []string `json:"unlocked_badges"`
 }
+
+// FeelCalendarResponse represents a month or year of daily check-in cells
+type FeelCalendarResponse struct {
+	Year  int                `json:"year"`
+	Month int                `json:"month,omitempty"` // Omitted for a whole-year request
+	From  string             `json:"from"`
+	To    string             `json:"to"`
+	Days  []FeelCalendarCell `json:"days"`
+}
+
+// FeelCalendarCell represents a single day in the calendar heatmap
+type FeelCalendarCell struct {
+	Date   string `json:"date"`
+	Status string `json:"status"` // checked_in, missing, future
+	Score  int    `json:"score,omitempty"`
+	Color  string `json:"color,omitempty"`
+	Emoji  string `json:"emoji,omitempty"`
+}
+
+// RescoreRequest represents an admin request to recompute historical feel scores
+type RescoreRequest struct {
+	Version int `json:"version"` // Defaults to the active formula version; does not change it
+}
+
+// FeelHistoryQuery represents query parameters for GET /api/feels/history
+type FeelHistoryQuery struct {
+	Cursor   string `query:"cursor"`
+	Limit    int    `query:"limit"`
+	From     string `query:"from"` // YYYY-MM-DD
+	To       string `query:"to"`   // YYYY-MM-DD
+	MinScore int    `query:"min_score"`
+	MaxScore int    `query:"max_score"`
+	Emoji    string `query:"emoji"`
+	Sort     string `query:"sort"`   // desc (default) or asc
+	Fields   string `query:"fields"` // Comma-separated, e.g. feel_score,mood_emoji
+}
+
+// FeelHistoryResponse represents a page of check-in history
+type FeelHistoryResponse struct {
+	Data       []FeelHistoryItem `json:"data"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	HasMore    bool              `json:"has_more"`
+	Limit      int               `json:"limit"`
+}
+
+// FeelHistoryItem is a check-in with only the requested fields populated.
+// id and check_date are always present.
+type FeelHistoryItem struct {
+	ID           string       `json:"id"`
+	CheckDate    string       `json:"check_date"`
+	MoodScore    *int         `json:"mood_score,omitempty"`
+	EnergyScore  *int         `json:"energy_score,omitempty"`
+	FeelScore    *int         `json:"feel_score,omitempty"`
+	MoodEmoji    *string      `json:"mood_emoji,omitempty"`
+	Note         *string      `json:"note,omitempty"`
+	ColorHex     *string      `json:"color_hex,omitempty"`
+	ScoreVersion *int         `json:"score_version,omitempty"`
+	CreatedAt    *string      `json:"created_at,omitempty"`
+	Tags         []HistoryTag `json:"tags,omitempty"`
+	Emotions     []string     `json:"emotions,omitempty"`
+}
+
+// HistoryTag is a compact tag reference in history items
+type HistoryTag struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Icon string `json:"icon"`
+}
+
+// EmotionResponse represents a catalog emotion with its localized label
+type EmotionResponse struct {
+	ID           string  `json:"id"`
+	Label        string  `json:"label"`
+	Quadrant     string  `json:"quadrant"`
+	Valence      float64 `json:"valence"`
+	Arousal      float64 `json:"arousal"`
+	DefaultEmoji string  `json:"default_emoji"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+// Forecast statuses
+const (
+	ForecastOK            = "ok"
+	ForecastNotEnoughData = "not_enough_data"
+)
+
+// ForecastResponse represents tomorrow's forecast. With too little history only
+// status, message, check_ins and required_check_ins are set.
+type ForecastResponse struct {
+	Status           string            `json:"status"`
+	Message          string            `json:"message,omitempty"`
+	CheckIns         int               `json:"check_ins"`
+	RequiredCheckIns int               `json:"required_check_ins"`
+	Date             string            `json:"date,omitempty"` // YYYY-MM-DD
+	Method           string            `json:"method,omitempty"`
+	FeelScore        *ForecastValue    `json:"feel_score,omitempty"`
+	EnergyScore      *ForecastValue    `json:"energy_score,omitempty"`
+	Accuracy         *ForecastAccuracy `json:"accuracy,omitempty"`
+}
+
+// ForecastValue represents a point forecast with a 95% confidence band
+type ForecastValue struct {
+	Value float64 `json:"value"`
+	Low   float64 `json:"low"`
+	High  float64 `json:"high"`
+}
+
+// ForecastAccuracy summarizes past forecasts that have an actual check-in
+type ForecastAccuracy struct {
+	Evaluated     int     `json:"evaluated"`
+	FeelMAE       float64 `json:"feel_mae"`        // Mean absolute error
+	EnergyMAE     float64 `json:"energy_mae"`      // Mean absolute error
+	FeelWithinPct float64 `json:"feel_within_pct"` // Share of actual feel scores inside the band
+}
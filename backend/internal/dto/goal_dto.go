@@ -0,0 +1,39 @@
+package dto
+
+import "github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+
+// CreateGoalRequest represents a request to create a goal
+type CreateGoalRequest struct {
+	Type   string `json:"type"`   // check_in_days, avg_score, streak_days
+	Target int    `json:"target"` // Days, or a feel score for avg_score
+	Period string `json:"period"` // week, month
+	Title  string `json:"title"`
+}
+
+// UpdateGoalRequest represents a partial goal update
+type UpdateGoalRequest struct {
+	Title    *string `json:"title"`
+	Target   *int    `json:"target"`
+	IsActive *bool   `json:"is_active"`
+}
+
+// GoalResponse represents a goal with progress for the current period
+type GoalResponse struct {
+	ID          string  `json:"id"`
+	Type        string  `json:"type"`
+	Title       string  `json:"title"`
+	Target      int     `json:"target"`
+	Period      string  `json:"period"`
+	IsActive    bool    `json:"is_active"`
+	Progress    float64 `json:"progress"`
+	Percent     int     `json:"percent"` // 0-100
+	PeriodStart string  `json:"period_start"`
+	PeriodEnd   string  `json:"period_end"`
+	Completed   bool    `json:"completed"`
+}
+
+// GoalCompletionsResponse represents completed goal history, newest first
+type GoalCompletionsResponse struct {
+	Data   []models.GoalCompletion `json:"data"`
+	Unseen int                     `json:"unseen"` // Completions returned here for the first time
+}
@@ -0,0 +1,61 @@
+package dto
+
+import "time"
+
+// SaveGratitudeRequest represents the request body for PUT /api/gratitude
+type SaveGratitudeRequest struct {
+	Date  string   `json:"date"`  // YYYY-MM-DD, defaults to today
+	Items []string `json:"items"` // 1-3 short items, replaces the day's list
+}
+
+// GratitudeHistoryQuery represents query parameters for GET /api/gratitude
+type GratitudeHistoryQuery struct {
+	Cursor string `query:"cursor"`
+	Limit  int    `query:"limit"`
+	From   string `query:"from"` // YYYY-MM-DD
+	To     string `query:"to"`   // YYYY-MM-DD
+}
+
+// GratitudeEntryResponse represents one day's gratitude list
+type GratitudeEntryResponse struct {
+	ID          string    `json:"id"`
+	Date        string    `json:"date"`
+	Items       []string  `json:"items"`
+	FeelCheckID *string   `json:"feel_check_id"` // The same day's check-in, if any
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// GratitudeHistoryResponse represents a page of gratitude entries, newest first
+type GratitudeHistoryResponse struct {
+	Data       []GratitudeEntryResponse `json:"data"`
+	NextCursor string                   `json:"next_cursor,omitempty"`
+	HasMore    bool                     `json:"has_more"`
+	Limit      int                      `json:"limit"`
+}
+
+// GratitudeStreakResponse represents the gratitude journal streak, counted
+// separately from check-in streaks
+type GratitudeStreakResponse struct {
+	CurrentStreak int     `json:"current_streak"`
+	LongestStreak int     `json:"longest_streak"`
+	TotalDays     int     `json:"total_days"`
+	LastEntryDate *string `json:"last_entry_date"`
+}
+
+// GratitudeExportQuery represents query parameters for GET /api/gratitude/export
+type GratitudeExportQuery struct {
+	Format string `query:"format"` // csv (default) or jsonl
+	From   string `query:"from"`   // YYYY-MM-DD, inclusive
+	To     string `query:"to"`     // YYYY-MM-DD, inclusive
+}
+
+// GratitudeExportRow is one day in a CSV or JSON Lines gratitude export
+type GratitudeExportRow struct {
+	ID          string    `json:"id"`
+	Date        string    `json:"date"`
+	Items       []string  `json:"items"`
+	FeelCheckID string    `json:"feel_check_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
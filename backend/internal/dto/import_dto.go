@@ -0,0 +1,34 @@
+package dto
+
+// MoodImportResponse summarizes an import from another mood tracker. On a dry
+// run nothing is saved and Imported is what would be created.
+type MoodImportResponse struct {
+	Format     string              `json:"format"`
+	DryRun     bool                `json:"dry_run"`
+	Rows       int                 `json:"rows"`       // Data rows read
+	Days       int                 `json:"days"`       // Distinct days with a mood
+	Imported   int                 `json:"imported"`   // Check-ins created
+	Duplicates int                 `json:"duplicates"` // Days that already had a check-in
+	Skipped    int                 `json:"skipped"`    // Rows that could not be mapped
+	From       string              `json:"from,omitempty"`
+	To         string              `json:"to,omitempty"`
+	Errors     []MoodImportError   `json:"errors"`  // First rows that could not be mapped
+	Preview    []MoodImportPreview `json:"preview"` // First days, oldest first
+}
+
+// MoodImportError describes a row that was skipped
+type MoodImportError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// MoodImportPreview is one day as it would be stored
+type MoodImportPreview struct {
+	Date        string `json:"date"`
+	MoodScore   int    `json:"mood_score"`
+	EnergyScore int    `json:"energy_score"`
+	FeelScore   int    `json:"feel_score"`
+	MoodEmoji   string `json:"mood_emoji"`
+	Note        string `json:"note"`
+	Duplicate   bool   `json:"duplicate"` // Already checked in; will be skipped
+}
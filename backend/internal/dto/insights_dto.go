@@ -0,0 +1,165 @@
+package dto
+
+// FeelInsightsResponse represents mood trends and analytics over a time range
+type FeelInsightsResponse struct {
+	Range                 string             `json:"range"` // 30d, 90d, 1y
+	From                  string             `json:"from"`
+	To                    string             `json:"to"`
+	TotalCheckIns         int64              `json:"total_check_ins"`
+	Averages              InsightAverages    `json:"averages"`
+	DayOfWeek             []DayOfWeekPattern `json:"day_of_week"`
+	TimeOfDay             []TimeOfDayPattern `json:"time_of_day"`
+	MoodEnergyCorrelation *float64           `json:"mood_energy_correlation"` // Pearson r, null if not enough data
+	Distribution          []ScoreBucket      `json:"distribution"`
+	BestStreak            *MoodStreak        `json:"best_streak"`
+	WorstStreak           *MoodStreak        `json:"worst_streak"`
+	Volatility            InsightVolatility  `json:"volatility"`
+}
+
+// InsightAverages groups score averages by period granularity
+type InsightAverages struct {
+	Daily   []PeriodAverage `json:"daily"`
+	Weekly  []PeriodAverage `json:"weekly"`
+	Monthly []PeriodAverage `json:"monthly"`
+}
+
+// PeriodAverage represents average scores for a single day, week or month
+type PeriodAverage struct {
+	Period         string  `json:"period"` // Start date of the period (YYYY-MM-DD)
+	AvgFeelScore   float64 `json:"avg_feel_score"`
+	AvgMoodScore   float64 `json:"avg_mood_score"`
+	AvgEnergyScore float64 `json:"avg_energy_score"`
+	CheckInCount   int64   `json:"check_in_count"`
+}
+
+// DayOfWeekPattern represents average scores for a weekday
+type DayOfWeekPattern struct {
+	Weekday      int     `json:"weekday"` // ISO weekday, 1 = Monday ... 7 = Sunday
+	Name         string  `json:"name"`
+	AvgFeelScore float64 `json:"avg_feel_score"`
+	CheckInCount int64   `json:"check_in_count"`
+}
+
+// TimeOfDayPattern represents average scores by the time a check-in was made
+type TimeOfDayPattern struct {
+	Slot         string  `json:"slot"` // morning, afternoon, evening, night
+	AvgFeelScore float64 `json:"avg_feel_score"`
+	CheckInCount int64   `json:"check_in_count"`
+}
+
+// ScoreBucket represents the number of check-ins within a feel score band
+type ScoreBucket struct {
+	Min   int   `json:"min"`
+	Max   int   `json:"max"`
+	Count int64 `json:"count"`
+}
+
+// MoodStreak represents a run of consecutive days within a score band
+type MoodStreak struct {
+	StartDate    string  `json:"start_date"`
+	EndDate      string  `json:"end_date"`
+	Days         int     `json:"days"`
+	AvgFeelScore float64 `json:"avg_feel_score"`
+}
+
+// InsightVolatility describes how much the feel score swings
+type InsightVolatility struct {
+	StdDev         float64 `json:"std_dev"`
+	AvgDailyChange float64 `json:"avg_daily_change"` // Mean absolute change between consecutive check-ins
+	Level          string  `json:"level"`            // low, moderate, high
+}
+
+// TagInsightsResponse ranks tags by their effect on feel and energy scores
+type TagInsightsResponse struct {
+	Range string       `json:"range"`
+	From  string       `json:"from"`
+	To    string       `json:"to"`
+	Tags  []TagInsight `json:"tags"`
+}
+
+// TagInsight compares scores on days with a tag against days without it
+type TagInsight struct {
+	TagID            string   `json:"tag_id"`
+	Name             string   `json:"name"`
+	Icon             string   `json:"icon"`
+	Uses             int64    `json:"uses"`
+	AvgFeelWith      float64  `json:"avg_feel_with"`
+	AvgFeelWithout   *float64 `json:"avg_feel_without"` // null if the tag was used on every check-in
+	FeelEffect       *float64 `json:"feel_effect"`
+	AvgEnergyWith    float64  `json:"avg_energy_with"`
+	AvgEnergyWithout *float64 `json:"avg_energy_without"`
+	EnergyEffect     *float64 `json:"energy_effect"`
+}
+
+// EmotionInsightsResponse groups check-ins by selected emotion and mood-meter quadrant
+type EmotionInsightsResponse struct {
+	Range     string            `json:"range"`
+	From      string            `json:"from"`
+	To        string            `json:"to"`
+	Emotions  []EmotionInsight  `json:"emotions"`
+	Quadrants []QuadrantInsight `json:"quadrants"`
+}
+
+// EmotionInsight summarizes check-ins tagged with one emotion
+type EmotionInsight struct {
+	EmotionID      string  `json:"emotion_id"`
+	Label          string  `json:"label"`
+	Emoji          string  `json:"emoji"`
+	Quadrant       string  `json:"quadrant"`
+	Count          int64   `json:"count"`
+	AvgFeelScore   float64 `json:"avg_feel_score"`
+	AvgEnergyScore float64 `json:"avg_energy_score"`
+}
+
+// QuadrantInsight summarizes how often emotions from a quadrant were selected
+type QuadrantInsight struct {
+	Quadrant string  `json:"quadrant"`
+	Count    int64   `json:"count"`
+	Share    float64 `json:"share"` // Fraction of all emotion selections
+}
+
+// HealthInsightsResponse correlates imported health metrics with check-in scores
+type HealthInsightsResponse struct {
+	Range   string                `json:"range"`
+	From    string                `json:"from"`
+	To      string                `json:"to"`
+	Metrics []HealthMetricInsight `json:"metrics"`
+}
+
+// HealthMetricInsight relates one metric to feel and energy scores on the same day
+type HealthMetricInsight struct {
+	Metric            string   `json:"metric"` // sleep_hours, steps, resting_heart_rate
+	Days              int64    `json:"days"`   // Days with both the metric and a check-in
+	Average           *float64 `json:"average"`
+	FeelCorrelation   *float64 `json:"feel_correlation"`   // Pearson r, null if not enough data
+	EnergyCorrelation *float64 `json:"energy_correlation"` // Pearson r, null if not enough data
+}
+
+// MindfulnessInsightsResponse summarizes mood lift per session type and how
+// session days compare with the day's check-in
+type MindfulnessInsightsResponse struct {
+	Range string                   `json:"range"`
+	From  string                   `json:"from"`
+	To    string                   `json:"to"`
+	Types []MindfulnessTypeInsight `json:"types"`
+	Days  MindfulnessDayInsight    `json:"days"`
+}
+
+// MindfulnessTypeInsight averages the before/after change for one session type
+type MindfulnessTypeInsight struct {
+	Type          string  `json:"type"`
+	Sessions      int64   `json:"sessions"` // Completed sessions
+	TotalMinutes  float64 `json:"total_minutes"`
+	AvgMoodLift   float64 `json:"avg_mood_lift"`
+	AvgEnergyLift float64 `json:"avg_energy_lift"`
+}
+
+// MindfulnessDayInsight compares check-in days with and without a session
+type MindfulnessDayInsight struct {
+	DaysWithSessions    int64    `json:"days_with_sessions"`
+	AvgFeelWithSessions *float64 `json:"avg_feel_with_sessions"`
+	DaysWithout         int64    `json:"days_without"`
+	AvgFeelWithout      *float64 `json:"avg_feel_without"`
+	FeelEffect          *float64 `json:"feel_effect"`         // With minus without
+	MinutesCorrelation  *float64 `json:"minutes_correlation"` // Pearson r of session minutes and feel score, null if not enough data
+}
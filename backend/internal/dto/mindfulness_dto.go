@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// StartMindfulnessRequest represents the request body for POST /api/mindfulness/sessions
+type StartMindfulnessRequest struct {
+	Type         string `json:"type"` // breathing, meditation, body_scan or mindful_walk
+	MoodBefore   int    `json:"mood_before"`
+	EnergyBefore int    `json:"energy_before"`
+}
+
+// CompleteMindfulnessRequest represents the request body for
+// POST /api/mindfulness/sessions/:id/complete
+type CompleteMindfulnessRequest struct {
+	MoodAfter       int `json:"mood_after"`
+	EnergyAfter     int `json:"energy_after"`
+	DurationSeconds int `json:"duration_seconds"` // Time actually practiced; defaults to the time since start
+}
+
+// MindfulnessSessionQuery represents query parameters for GET /api/mindfulness/sessions
+type MindfulnessSessionQuery struct {
+	Type  string `query:"type"`
+	From  string `query:"from"` // YYYY-MM-DD
+	To    string `query:"to"`   // YYYY-MM-DD
+	Limit int    `query:"limit"`
+}
+
+// MindfulnessSessionResponse represents a mindfulness session
+type MindfulnessSessionResponse struct {
+	ID              string     `json:"id"`
+	Type            string     `json:"type"`
+	Status          string     `json:"status"` // in_progress, completed or abandoned
+	Date            string     `json:"date"`
+	StartedAt       time.Time  `json:"started_at"`
+	CompletedAt     *time.Time `json:"completed_at"`
+	DurationSeconds int        `json:"duration_seconds"`
+	MoodBefore      int        `json:"mood_before"`
+	EnergyBefore    int        `json:"energy_before"`
+	MoodAfter       *int       `json:"mood_after"`
+	EnergyAfter     *int       `json:"energy_after"`
+	MoodDelta       *int       `json:"mood_delta"`
+	EnergyDelta     *int       `json:"energy_delta"`
+}
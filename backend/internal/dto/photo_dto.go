@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// PhotoResponse represents a check-in photo. URLs are signed and stop working
+// at ExpiresAt; list the photos again for fresh ones.
+type PhotoResponse struct {
+	ID           string    `json:"id"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	ContentType  string    `json:"content_type"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	Size         int       `json:"size"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+// DailyPromptResponse represents the reflection prompt for the day
+type DailyPromptResponse struct {
+	ID       string `json:"id"` // Send as prompt_id when checking in
+	Text     string `json:"text"`
+	Category string `json:"category"`
+	Locale   string `json:"locale"`
+	Date     string `json:"date"`
+	Answered bool   `json:"answered"` // Today's check-in answered this prompt
+}
+
+// CreatePromptRequest represents the request body for POST /api/admin/prompts
+type CreatePromptRequest struct {
+	Text     string `json:"text"`
+	Category string `json:"category"`
+	Locale   string `json:"locale"`    // Defaults to en
+	MinScore int    `json:"min_score"` // Preferred feel score range, defaults to 1-100
+	MaxScore int    `json:"max_score"`
+	Active   *bool  `json:"active"` // Defaults to true
+}
+
+// UpdatePromptRequest represents the request body for PUT /api/admin/prompts/:id.
+// Omitted fields are left unchanged.
+type UpdatePromptRequest struct {
+	Text     *string `json:"text"`
+	Category *string `json:"category"`
+	Locale   *string `json:"locale"`
+	MinScore *int    `json:"min_score"`
+	MaxScore *int    `json:"max_score"`
+	Active   *bool   `json:"active"`
+}
+
+// PromptListQuery represents query parameters for GET /api/admin/prompts
+type PromptListQuery struct {
+	Locale   string `query:"locale"`
+	Category string `query:"category"`
+}
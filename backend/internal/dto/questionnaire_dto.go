@@ -0,0 +1,116 @@
+package dto
+
+import "time"
+
+// QuestionnaireDefinition describes a self-assessment instrument. Definitions are
+// data, so new instruments can be added through QUESTIONNAIRES_FILE.
+type QuestionnaireDefinition struct {
+	ID             string                `json:"id"` // e.g. phq9
+	Version        int                   `json:"version"`
+	Name           string                `json:"name"`
+	Description    string                `json:"description"`
+	Instructions   string                `json:"instructions"`
+	LookbackDays   int                   `json:"lookback_days"` // Period the questions ask about
+	IntervalDays   int                   `json:"interval_days"` // Default time between prompts
+	Options        []QuestionnaireOption `json:"options"`       // Answer choices shared by all items
+	Items          []QuestionnaireItem   `json:"items"`
+	Multiplier     int                   `json:"multiplier,omitempty"` // Applied to the item sum, e.g. 4 for WHO-5 percentages
+	HigherIsBetter bool                  `json:"higher_is_better"`
+	Bands          []SeverityBand        `json:"bands"`
+}
+
+// QuestionnaireOption is an answer choice and the points it scores
+type QuestionnaireOption struct {
+	Value int    `json:"value"`
+	Label string `json:"label"`
+}
+
+// QuestionnaireItem is one question
+type QuestionnaireItem struct {
+	ID     string `json:"id"`
+	Text   string `json:"text"`
+	Safety bool   `json:"safety,omitempty"` // Any answer above the lowest option shows crisis resources
+}
+
+// SeverityBand labels a score range (inclusive)
+type SeverityBand struct {
+	Min      int    `json:"min"`
+	Max      int    `json:"max"`
+	Severity string `json:"severity"` // Stable key, e.g. moderate
+	Label    string `json:"label"`
+}
+
+// QuestionnaireSummary represents a questionnaire with the user's schedule and latest result
+type QuestionnaireSummary struct {
+	ID             string                      `json:"id"`
+	Name           string                      `json:"name"`
+	Description    string                      `json:"description"`
+	ItemCount      int                         `json:"item_count"`
+	MaxScore       int                         `json:"max_score"`
+	HigherIsBetter bool                        `json:"higher_is_better"`
+	Schedule       QuestionnaireScheduleStatus `json:"schedule"`
+	LastResult     *QuestionnaireResultItem    `json:"last_result"`
+}
+
+// QuestionnaireScheduleStatus represents when the user is next prompted
+type QuestionnaireScheduleStatus struct {
+	Enabled      bool       `json:"enabled"`
+	IntervalDays int        `json:"interval_days"`
+	NextDueAt    *time.Time `json:"next_due_at"`
+	Due          bool       `json:"due"`
+}
+
+// UpdateQuestionnaireScheduleRequest represents the request body for PUT /api/questionnaires/:id/schedule
+type UpdateQuestionnaireScheduleRequest struct {
+	Enabled      bool `json:"enabled"`
+	IntervalDays int  `json:"interval_days"` // 7-180, defaults to the instrument's interval
+}
+
+// SubmitQuestionnaireRequest represents the request body for POST /api/questionnaires/:id/results
+type SubmitQuestionnaireRequest struct {
+	Answers map[string]int `json:"answers"` // Item ID to option value; every item is required
+}
+
+// QuestionnaireResultItem represents a scored questionnaire
+type QuestionnaireResultItem struct {
+	ID            string         `json:"id"`
+	Version       int            `json:"version"`
+	Score         int            `json:"score"`
+	MaxScore      int            `json:"max_score"`
+	Severity      string         `json:"severity"`
+	SeverityLabel string         `json:"severity_label"`
+	SafetyFlag    bool           `json:"safety_flag"`
+	Answers       map[string]int `json:"answers,omitempty"`
+	CompletedAt   time.Time      `json:"completed_at"`
+}
+
+// QuestionnaireResultResponse represents a just-submitted result. Resources are
+// set when a safety item was answered.
+type QuestionnaireResultResponse struct {
+	QuestionnaireResultItem
+	Resources []WellbeingResource `json:"resources"`
+}
+
+// QuestionnaireTrendResponse represents questionnaire scores next to check-in averages
+type QuestionnaireTrendResponse struct {
+	QuestionnaireID string                    `json:"questionnaire_id"`
+	Range           string                    `json:"range"`
+	From            string                    `json:"from"`
+	To              string                    `json:"to"`
+	MaxScore        int                       `json:"max_score"`
+	HigherIsBetter  bool                      `json:"higher_is_better"`
+	Bands           []SeverityBand            `json:"bands"`
+	Points          []QuestionnaireTrendPoint `json:"points"`
+}
+
+// QuestionnaireTrendPoint is one result with check-in averages over the period it asks about
+type QuestionnaireTrendPoint struct {
+	Date           string   `json:"date"`
+	Score          int      `json:"score"`
+	Severity       string   `json:"severity"`
+	SeverityLabel  string   `json:"severity_label"`
+	AvgFeelScore   *float64 `json:"avg_feel_score"` // Null without check-ins in the lookback window
+	AvgMoodScore   *float64 `json:"avg_mood_score"`
+	AvgEnergyScore *float64 `json:"avg_energy_score"`
+	CheckInCount   int64    `json:"check_in_count"`
+}
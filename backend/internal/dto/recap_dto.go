@@ -0,0 +1,44 @@
+package dto
+
+import "github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+
+// RecapQuery represents query parameters for GET /api/feels/recaps
+type RecapQuery struct {
+	Type  string `query:"type"` // week, month or empty for both
+	Limit int    `query:"limit"`
+}
+
+// RecapListResponse represents the user's recaps, newest first
+type RecapListResponse struct {
+	Data   []RecapResponse `json:"data"`
+	Unread int             `json:"unread"` // Recaps returned here for the first time
+}
+
+// RecapResponse represents a weekly or monthly recap
+type RecapResponse struct {
+	ID             string             `json:"id"`
+	PeriodType     string             `json:"period_type"`
+	PeriodStart    string             `json:"period_start"` // YYYY-MM-DD
+	PeriodEnd      string             `json:"period_end"`   // YYYY-MM-DD, inclusive
+	CheckIns       int                `json:"check_ins"`
+	AvgScore       *float64           `json:"avg_score"`
+	PrevAvgScore   *float64           `json:"prev_avg_score"`
+	ScoreChange    *float64           `json:"score_change"`
+	BestDay        *RecapDay          `json:"best_day"`
+	WorstDay       *RecapDay          `json:"worst_day"`
+	CurrentStreak  int                `json:"current_streak"` // Streak as of the period's last day
+	LongestStreak  int                `json:"longest_streak"` // Longest run within the period
+	TopTags        []models.RecapItem `json:"top_tags"`
+	TopEmotions    []models.RecapItem `json:"top_emotions"`
+	VibesReceived  int                `json:"vibes_received"`
+	GratitudeDays  int                `json:"gratitude_days"`
+	GratitudeItems int                `json:"gratitude_items"`
+	BadgesEarned   []string           `json:"badges_earned"`
+	IsNew          bool               `json:"is_new"`
+}
+
+// RecapDay represents a notable day in a recap
+type RecapDay struct {
+	Date      string `json:"date"`
+	FeelScore int    `json:"feel_score"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+// ReminderSettingsRequest represents a request to replace reminder settings
+type ReminderSettingsRequest struct {
+	Enabled  bool     `json:"enabled"`
+	Times    []string `json:"times"`    // Local "HH:MM", up to 5
+	Weekdays []int    `json:"weekdays"` // ISO weekdays, 1 = Monday ... 7 = Sunday
+	Timezone string   `json:"timezone"` // IANA name; defaults to the account time zone
+}
+
+// ReminderSettingsResponse represents the user's reminder settings
+type ReminderSettingsResponse struct {
+	Enabled  bool     `json:"enabled"`
+	Times    []string `json:"times"`
+	Weekdays []int    `json:"weekdays"`
+	Timezone string   `json:"timezone"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+// FeelSearchQuery represents query parameters for GET /api/feels/search
+type FeelSearchQuery struct {
+	Q        string `query:"q"`
+	From     string `query:"from"` // YYYY-MM-DD
+	To       string `query:"to"`   // YYYY-MM-DD
+	MinScore int    `query:"min_score"`
+	MaxScore int    `query:"max_score"`
+	Emoji    string `query:"emoji"`
+	Cursor   string `query:"cursor"`
+	Limit    int    `query:"limit"`
+}
+
+// FeelSearchResponse represents a page of note search results. A page may be
+// short, or empty, while HasMore is set when a request hit its scan limit.
+type FeelSearchResponse struct {
+	Data       []FeelSearchResult `json:"data"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+	HasMore    bool               `json:"has_more"`
+}
+
+// FeelSearchResult represents a check-in whose note matched the query
+type FeelSearchResult struct {
+	ID          string `json:"id"`
+	CheckDate   string `json:"check_date"`
+	MoodScore   int    `json:"mood_score"`
+	EnergyScore int    `json:"energy_score"`
+	FeelScore   int    `json:"feel_score"`
+	MoodEmoji   string `json:"mood_emoji"`
+	ColorHex    string `json:"color_hex"`
+	Snippet     string `json:"snippet"` // HTML-escaped; matched words wrapped in <b></b>
+}
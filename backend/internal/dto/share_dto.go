@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+// CreateShareGrantRequest represents the request body for POST /api/shares
+type CreateShareGrantRequest struct {
+	Label         string `json:"label"`
+	From          string `json:"from"` // YYYY-MM-DD, required
+	To            string `json:"to"`   // YYYY-MM-DD, empty keeps the range open
+	IncludeNotes  bool   `json:"include_notes"`
+	ExpiresInDays int    `json:"expires_in_days"` // 1-90, default 14
+}
+
+// ShareGrantResponse represents a share grant. URL is only set when the grant
+// is created; the token cannot be recovered afterwards.
+type ShareGrantResponse struct {
+	ID             string     `json:"id"`
+	Label          string     `json:"label"`
+	From           string     `json:"from"`
+	To             *string    `json:"to"`
+	IncludeNotes   bool       `json:"include_notes"`
+	Status         string     `json:"status"` // active, expired or revoked
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	LastAccessedAt *time.Time `json:"last_accessed_at"`
+	AccessCount    int64      `json:"access_count"`
+	CreatedAt      time.Time  `json:"created_at"`
+	URL            string     `json:"url,omitempty"`
+}
+
+// ShareAccessResponse represents one logged use of a share grant
+type ShareAccessResponse struct {
+	Resource   string    `json:"resource"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	AccessedAt time.Time `json:"accessed_at"`
+}
+
+// SharedViewResponse describes what a share link gives access to
+type SharedViewResponse struct {
+	Label        string    `json:"label"`
+	From         string    `json:"from"`
+	To           string    `json:"to"`
+	IncludeNotes bool      `json:"include_notes"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+)
+
+// SyncFeelChecksRequest represents a batch of check-ins recorded offline
+type SyncFeelChecksRequest struct {
+	Items []SyncFeelCheckItem `json:"items"`
+}
+
+// SyncFeelCheckItem is a client-side check-in, created, edited or deleted offline
+type SyncFeelCheckItem struct {
+	ID              string    `json:"id"` // Client-generated UUID
+	MoodScore       int       `json:"mood_score"`
+	EnergyScore     int       `json:"energy_score"`
+	MoodEmoji       string    `json:"mood_emoji"`
+	Note            string    `json:"note"`
+	TagIDs          []string  `json:"tag_ids"`
+	EmotionIDs      []string  `json:"emotion_ids"`
+	PromptID        string    `json:"prompt_id"`
+	CheckDate       string    `json:"check_date"` // YYYY-MM-DD, the client's local day
+	ClientCreatedAt time.Time `json:"client_created_at"`
+	ClientUpdatedAt time.Time `json:"client_updated_at"`
+	Deleted         bool      `json:"deleted"`
+}
+
+// SyncFeelChecksResponse represents per-item sync results. Clients pull
+// GET /api/feels/sync with their own token to pick up other changes.
+type SyncFeelChecksResponse struct {
+	Results []SyncItemResult `json:"results"`
+}
+
+// SyncItemResult is the outcome for one item, in request order.
+// Status is created, updated, deleted, unchanged, stale, duplicate_date or rejected.
+type SyncItemResult struct {
+	ID      string            `json:"id"`
+	Status  string            `json:"status"`
+	Message string            `json:"message,omitempty"`
+	Check   *models.FeelCheck `json:"check,omitempty"` // Server copy the client should keep
+}
+
+// FeelDeltaResponse represents check-ins changed since a sync token
+type FeelDeltaResponse struct {
+	Changed   []models.FeelCheck `json:"changed"`
+	Deleted   []string           `json:"deleted"`
+	SyncToken string             `json:"sync_token"`
+	HasMore   bool               `json:"has_more"` // Call again with sync_token for the rest
+}
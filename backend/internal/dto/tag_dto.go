@@ -0,0 +1,20 @@
+package dto
+
+// CreateTagRequest represents a request to create a user-defined tag
+type CreateTagRequest struct {
+	Name     string `json:"name"`
+	Category string `json:"category"` // activity, context, health
+	Icon     string `json:"icon"`
+}
+
+// UpdateTagRequest represents a request to rename or restyle a user-defined tag
+type UpdateTagRequest struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Icon     string `json:"icon"`
+}
+
+// SetFeelTagsRequest replaces the tags attached to a check-in
+type SetFeelTagsRequest struct {
+	TagIDs []string `json:"tag_ids"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+
+// WellbeingResource is a coping tip or helpline shown alongside an alert
+type WellbeingResource struct {
+	Kind  string `json:"kind"` // tip, helpline
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+	Phone string `json:"phone,omitempty"`
+	URL   string `json:"url,omitempty"`
+}
+
+// WellbeingResponse represents active alerts and supportive resources
+type WellbeingResponse struct {
+	Alerts    []models.WellbeingAlert `json:"alerts"`
+	Resources []WellbeingResource     `json:"resources"` // Empty when there are no active alerts
+}
@@ -148,6 +148,41 @@ func (h *AuthHandler) DeleteAccount(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{"message": "Account deleted successfully"})
 }
 
+// UpdateTimezone handles PUT /api/auth/timezone
+func (h *AuthHandler) UpdateTimezone(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var req dto.UpdateTimezoneRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	if err := h.authService.UpdateTimezone(userID, req.Timezone); err != nil {
+		if errors.Is(err, services.ErrInvalidTimezone) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: "Invalid timezone",
+			})
+		}
+		if errors.Is(err, services.ErrUserNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: "User not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to update timezone",
+		})
+	}
+
+	return c.JSON(fiber.Map{"message": "Timezone updated"})
+}
+
 // AppleSignIn handles Sign in with Apple (Guideline 4.8).
 func (h *AuthHandler) AppleSignIn(c *fiber.Ctx) error {
 	var req dto.AppleSignInRequest
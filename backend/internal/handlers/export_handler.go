@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ExportHandler struct {
+	exportService *services.ExportService
+}
+
+func NewExportHandler(exportService *services.ExportService) *ExportHandler {
+	return &ExportHandler{exportService: exportService}
+}
+
+// ExportFeels handles GET /api/feels/export?format=csv|jsonl&from=&to=
+func (h *ExportHandler) ExportFeels(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var query dto.FeelExportQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid query parameters",
+		})
+	}
+
+	if err := h.exportService.ExportFeels(userID, &query, c.Response().BodyWriter()); err != nil {
+		c.Response().ResetBody()
+		if errors.Is(err, services.ErrInvalidExportFormat) || errors.Is(err, services.ErrInvalidExportRange) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to export check-ins",
+		})
+	}
+
+	filename := "feelsy-" + time.Now().Format("2006-01-02")
+	if query.Format == services.ExportJSONL {
+		c.Set(fiber.HeaderContentType, "application/x-ndjson")
+		filename += ".jsonl"
+	} else {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		filename += ".csv"
+	}
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return nil
+}
+
+// ExportGratitude handles GET /api/gratitude/export?format=csv|jsonl&from=&to=
+func (h *ExportHandler) ExportGratitude(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var query dto.GratitudeExportQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid query parameters",
+		})
+	}
+
+	if err := h.exportService.ExportGratitude(userID, &query, c.Response().BodyWriter()); err != nil {
+		c.Response().ResetBody()
+		if errors.Is(err, services.ErrInvalidExportFormat) || errors.Is(err, services.ErrInvalidExportRange) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to export gratitude entries",
+		})
+	}
+
+	filename := "feelsy-gratitude-" + time.Now().Format("2006-01-02")
+	if query.Format == services.ExportJSONL {
+		c.Set(fiber.HeaderContentType, "application/x-ndjson")
+		filename += ".jsonl"
+	} else {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		filename += ".csv"
+	}
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return nil
+}
+
+// CreateCalendarFeed handles POST /api/feels/export/calendar
+func (h *ExportHandler) CreateCalendarFeed(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	feed, err := h.exportService.CreateCalendarFeed(userID, c.BaseURL())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to create calendar feed",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(feed)
+}
+
+// RevokeCalendarFeed handles DELETE /api/feels/export/calendar
+func (h *ExportHandler) RevokeCalendarFeed(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	if err := h.exportService.RevokeCalendarFeed(userID); err != nil {
+		if errors.Is(err, services.ErrCalendarFeedNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to revoke calendar feed",
+		})
+	}
+
+	return c.JSON(fiber.Map{"message": "Calendar feed revoked successfully"})
+}
+
+// GetCalendarFeed handles GET /api/calendar/:token.ics (public, authorized by the token)
+func (h *ExportHandler) GetCalendarFeed(c *fiber.Ctx) error {
+	if err := h.exportService.WriteCalendarFeed(c.Params("token"), c.Response().BodyWriter()); err != nil {
+		c.Response().ResetBody()
+		if errors.Is(err, services.ErrCalendarFeedNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to build calendar feed",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderCacheControl, "private, max-age=900")
+	return nil
+}
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"errors"
 	"strconv"
+	"time"
 
 	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
 	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
@@ -32,7 +34,8 @@ func (h *FeelHandler) CreateFeelCheck(c *fiber.Ctx) error {
 		})
 	}
 
-	check, err := h.service.CreateFeelCheck(userID, req.MoodScore, req.EnergyScore, req.MoodEmoji, req.Note)
+	locale := c.Query("locale", c.Get(fiber.HeaderAcceptLanguage))
+	check, err := h.service.CreateFeelCheck(userID, &req, locale)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error":   true,
@@ -43,6 +46,99 @@ func (h *FeelHandler) CreateFeelCheck(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(check)
 }
 
+// SetFeelCheckTags handles PUT /api/feels/:id/tags
+func (h *FeelHandler) SetFeelCheckTags(c *fiber.Ctx) error {
+	userToken := c.Locals("user").(*jwt.Token)
+	claims := userToken.Claims.(jwt.MapClaims)
+	userID, _ := uuid.Parse(claims["sub"].(string))
+
+	checkID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid check-in ID",
+		})
+	}
+
+	var req dto.SetFeelTagsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	check, err := h.service.SetFeelCheckTags(userID, checkID, req.TagIDs)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTag) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Check-in not found",
+		})
+	}
+
+	return c.JSON(check)
+}
+
+// SyncFeelChecks handles POST /api/feels/sync
+func (h *FeelHandler) SyncFeelChecks(c *fiber.Ctx) error {
+	userToken := c.Locals("user").(*jwt.Token)
+	claims := userToken.Claims.(jwt.MapClaims)
+	userID, _ := uuid.Parse(claims["sub"].(string))
+
+	var req dto.SyncFeelChecksRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	resp, err := h.service.SyncFeelChecks(userID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrSyncBatchSize) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to sync check-ins",
+		})
+	}
+
+	return c.JSON(resp)
+}
+
+// GetFeelDelta handles GET /api/feels/sync?since=<sync_token>
+func (h *FeelHandler) GetFeelDelta(c *fiber.Ctx) error {
+	userToken := c.Locals("user").(*jwt.Token)
+	claims := userToken.Claims.(jwt.MapClaims)
+	userID, _ := uuid.Parse(claims["sub"].(string))
+
+	delta, err := h.service.GetFeelDelta(userID, c.Query("since"))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSyncToken) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch changes",
+		})
+	}
+
+	return c.JSON(delta)
+}
+
 // GetTodayCheck handles GET /api/feels/today
 func (h *FeelHandler) GetTodayCheck(c *fiber.Ctx) error {
 	userToken := c.Locals("user").(*jwt.Token)
@@ -66,14 +162,23 @@ func (h *FeelHandler) GetFeelHistory(c *fiber.Ctx) error {
 	claims := userToken.Claims.(jwt.MapClaims)
 	userID, _ := uuid.Parse(claims["sub"].(string))
 
-	limit, _ := strconv.Atoi(c.Query("limit", "20"))
-	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	var req dto.FeelHistoryQuery
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid query parameters",
+		})
+	}
 
-	if limit > 100 {
-		limit = 100
+	opts, err := h.service.ParseHistoryQuery(&req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
 	}
 
-	checks, total, err := h.service.GetFeelHistory(userID, limit, offset)
+	history, err := h.service.GetFeelHistory(userID, opts)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   true,
@@ -81,12 +186,7 @@ func (h *FeelHandler) GetFeelHistory(c *fiber.Ctx) error {
 		})
 	}
 
-	return c.JSON(fiber.Map{
-		"data":   checks,
-		"total":  total,
-		"limit":  limit,
-		"offset": offset,
-	})
+	return c.JSON(history)
 }
 
 // GetFeelStats handles GET /api/feels/stats
@@ -106,6 +206,93 @@ func (h *FeelHandler) GetFeelStats(c *fiber.Ctx) error {
 	return c.JSON(stats)
 }
 
+// GetCalendar handles GET /api/feels/calendar?year=&month=
+func (h *FeelHandler) GetCalendar(c *fiber.Ctx) error {
+	userToken := c.Locals("user").(*jwt.Token)
+	claims := userToken.Claims.(jwt.MapClaims)
+	userID, _ := uuid.Parse(claims["sub"].(string))
+
+	year, err := strconv.Atoi(c.Query("year", strconv.Itoa(time.Now().Year())))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid year",
+		})
+	}
+	month, err := strconv.Atoi(c.Query("month", "0"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid month",
+		})
+	}
+
+	calendar, err := h.service.GetCalendar(userID, year, month)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCalendarPeriod) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch calendar",
+		})
+	}
+
+	// Clients revalidate with If-None-Match; the ETag middleware answers 304 when unchanged
+	c.Set(fiber.HeaderCacheControl, "private, no-cache")
+	return c.JSON(calendar)
+}
+
+// GetEmotions handles GET /api/feels/emotions?locale=
+func (h *FeelHandler) GetEmotions(c *fiber.Ctx) error {
+	locale := c.Query("locale", c.Get(fiber.HeaderAcceptLanguage))
+	return c.JSON(fiber.Map{
+		"data": h.service.ListEmotions(locale),
+	})
+}
+
+// GetScale handles GET /api/feels/scale
+func (h *FeelHandler) GetScale(c *fiber.Ctx) error {
+	return c.JSON(h.service.GetScale())
+}
+
+// RescoreHistory handles POST /api/admin/feels/rescore
+func (h *FeelHandler) RescoreHistory(c *fiber.Ctx) error {
+	var req dto.RescoreRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+	if req.Version == 0 {
+		req.Version = h.service.GetScale().Version
+	}
+
+	updated, err := h.service.RescoreHistory(req.Version)
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownScoreVersion) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to rescore history",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"version":        req.Version,
+		"active_version": h.service.GetScale().Version, // Used for new check-ins
+		"updated":        updated,
+	})
+}
+
 // SendGoodVibe handles POST /api/feels/vibe
 func (h *FeelHandler) SendGoodVibe(c *fiber.Ctx) error {
 	userToken := c.Locals("user").(*jwt.Token)
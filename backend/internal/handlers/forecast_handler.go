@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ForecastHandler struct {
+	forecastService *services.ForecastService
+}
+
+func NewForecastHandler(forecastService *services.ForecastService) *ForecastHandler {
+	return &ForecastHandler{forecastService: forecastService}
+}
+
+// GetForecast handles GET /api/feels/forecast
+func (h *ForecastHandler) GetForecast(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	forecast, err := h.forecastService.GetForecast(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to build forecast",
+		})
+	}
+
+	return c.JSON(forecast)
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type GoalHandler struct {
+	goalService *services.GoalService
+}
+
+func NewGoalHandler(goalService *services.GoalService) *GoalHandler {
+	return &GoalHandler{goalService: goalService}
+}
+
+// ListGoals handles GET /api/goals
+func (h *GoalHandler) ListGoals(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	goals, err := h.goalService.ListGoals(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch goals",
+		})
+	}
+
+	return c.JSON(fiber.Map{"data": goals})
+}
+
+// CreateGoal handles POST /api/goals
+func (h *GoalHandler) CreateGoal(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var req dto.CreateGoalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	goal, err := h.goalService.CreateGoal(userID, &req)
+	if err != nil {
+		return goalError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(goal)
+}
+
+// UpdateGoal handles PUT /api/goals/:id
+func (h *GoalHandler) UpdateGoal(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	goalID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid goal ID",
+		})
+	}
+
+	var req dto.UpdateGoalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	goal, err := h.goalService.UpdateGoal(userID, goalID, &req)
+	if err != nil {
+		return goalError(c, err)
+	}
+
+	return c.JSON(goal)
+}
+
+// DeleteGoal handles DELETE /api/goals/:id
+func (h *GoalHandler) DeleteGoal(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	goalID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid goal ID",
+		})
+	}
+
+	if err := h.goalService.DeleteGoal(userID, goalID); err != nil {
+		return goalError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"message": "Goal deleted successfully"})
+}
+
+// ListCompletions handles GET /api/goals/completions?limit=
+func (h *GoalHandler) ListCompletions(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	completions, err := h.goalService.ListCompletions(userID, c.QueryInt("limit", 50))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch goal history",
+		})
+	}
+
+	return c.JSON(completions)
+}
+
+// goalError maps goal service errors to HTTP responses
+func goalError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrGoalNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+		Error: true, Message: err.Error(),
+	})
+}
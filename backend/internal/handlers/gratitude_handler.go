@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type GratitudeHandler struct {
+	gratitudeService *services.GratitudeService
+}
+
+func NewGratitudeHandler(gratitudeService *services.GratitudeService) *GratitudeHandler {
+	return &GratitudeHandler{gratitudeService: gratitudeService}
+}
+
+// SaveEntry handles PUT /api/gratitude
+func (h *GratitudeHandler) SaveEntry(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var req dto.SaveGratitudeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	entry, err := h.gratitudeService.SaveEntry(userID, &req)
+	if err != nil {
+		return gratitudeError(c, err)
+	}
+
+	return c.JSON(entry)
+}
+
+// GetHistory handles GET /api/gratitude
+func (h *GratitudeHandler) GetHistory(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var query dto.GratitudeHistoryQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid query parameters",
+		})
+	}
+
+	history, err := h.gratitudeService.GetHistory(userID, &query)
+	if err != nil {
+		return gratitudeError(c, err)
+	}
+
+	return c.JSON(history)
+}
+
+// GetStreak handles GET /api/gratitude/streak
+func (h *GratitudeHandler) GetStreak(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	streak, err := h.gratitudeService.GetStreak(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch gratitude streak",
+		})
+	}
+
+	return c.JSON(streak)
+}
+
+// DeleteEntry handles DELETE /api/gratitude/:date
+func (h *GratitudeHandler) DeleteEntry(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	if err := h.gratitudeService.DeleteEntry(userID, c.Params("date")); err != nil {
+		return gratitudeError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"message": "Gratitude entry deleted successfully"})
+}
+
+func gratitudeError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrGratitudeNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+		Error: true, Message: err.Error(),
+	})
+}
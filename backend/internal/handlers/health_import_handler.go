@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type HealthImportHandler struct {
+	importService *services.HealthImportService
+}
+
+func NewHealthImportHandler(importService *services.HealthImportService) *HealthImportHandler {
+	return &HealthImportHandler{importService: importService}
+}
+
+// CreateImport handles POST /api/imports/health (multipart form, field "file")
+func (h *HealthImportHandler) CreateImport(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "A file upload is required",
+		})
+	}
+
+	job, err := h.importService.CreateImport(userID, file)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidImportFile) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to store upload",
+		})
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(job)
+}
+
+// ListImports handles GET /api/imports/health
+func (h *HealthImportHandler) ListImports(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	jobs, err := h.importService.ListImports(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch imports",
+		})
+	}
+
+	return c.JSON(fiber.Map{"data": jobs})
+}
+
+// GetImport handles GET /api/imports/health/:id
+func (h *HealthImportHandler) GetImport(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	importID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid import ID",
+		})
+	}
+
+	job, err := h.importService.GetImport(userID, importID)
+	if err != nil {
+		if errors.Is(err, services.ErrImportNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch import",
+		})
+	}
+
+	return c.JSON(job)
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type InsightsHandler struct {
+	insightsService *services.InsightsService
+}
+
+func NewInsightsHandler(insightsService *services.InsightsService) *InsightsHandler {
+	return &InsightsHandler{insightsService: insightsService}
+}
+
+// GetInsights handles GET /api/feels/insights?range=30d|90d|1y
+func (h *InsightsHandler) GetInsights(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	insights, err := h.insightsService.GetInsights(userID, c.Query("range", "30d"))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidInsightRange) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch insights",
+		})
+	}
+
+	return c.JSON(insights)
+}
+
+// GetTagInsights handles GET /api/feels/insights/tags?range=30d|90d|1y
+func (h *InsightsHandler) GetTagInsights(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	insights, err := h.insightsService.GetTagInsights(userID, c.Query("range", "90d"))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidInsightRange) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch tag insights",
+		})
+	}
+
+	return c.JSON(insights)
+}
+
+// GetEmotionInsights handles GET /api/feels/insights/emotions?range=30d|90d|1y
+func (h *InsightsHandler) GetEmotionInsights(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	locale := c.Query("locale", c.Get(fiber.HeaderAcceptLanguage))
+	insights, err := h.insightsService.GetEmotionInsights(userID, c.Query("range", "30d"), locale)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidInsightRange) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch emotion insights",
+		})
+	}
+
+	return c.JSON(insights)
+}
+
+// GetHealthInsights handles GET /api/feels/insights/health?range=30d|90d|1y
+func (h *InsightsHandler) GetHealthInsights(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	insights, err := h.insightsService.GetHealthInsights(userID, c.Query("range", "90d"))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidInsightRange) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch health insights",
+		})
+	}
+
+	return c.JSON(insights)
+}
+
+// GetMindfulnessInsights handles GET /api/mindfulness/insights?range=30d|90d|1y
+func (h *InsightsHandler) GetMindfulnessInsights(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	insights, err := h.insightsService.GetMindfulnessInsights(userID, c.Query("range", "90d"))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidInsightRange) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch mindfulness insights",
+		})
+	}
+
+	return c.JSON(insights)
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type MindfulnessHandler struct {
+	mindfulnessService *services.MindfulnessService
+}
+
+func NewMindfulnessHandler(mindfulnessService *services.MindfulnessService) *MindfulnessHandler {
+	return &MindfulnessHandler{mindfulnessService: mindfulnessService}
+}
+
+// StartSession handles POST /api/mindfulness/sessions
+func (h *MindfulnessHandler) StartSession(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var req dto.StartMindfulnessRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	session, err := h.mindfulnessService.StartSession(userID, &req)
+	if err != nil {
+		return mindfulnessError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(session)
+}
+
+// CompleteSession handles POST /api/mindfulness/sessions/:id/complete
+func (h *MindfulnessHandler) CompleteSession(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid session ID",
+		})
+	}
+
+	var req dto.CompleteMindfulnessRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	session, err := h.mindfulnessService.CompleteSession(userID, sessionID, &req)
+	if err != nil {
+		return mindfulnessError(c, err)
+	}
+
+	return c.JSON(session)
+}
+
+// ListSessions handles GET /api/mindfulness/sessions
+func (h *MindfulnessHandler) ListSessions(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var query dto.MindfulnessSessionQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid query parameters",
+		})
+	}
+
+	sessions, err := h.mindfulnessService.ListSessions(userID, &query)
+	if err != nil {
+		return mindfulnessError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"data": sessions})
+}
+
+func mindfulnessError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrSessionNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrSessionCompleted), errors.Is(err, services.ErrSessionAbandoned):
+		return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+		Error: true, Message: err.Error(),
+	})
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type MoodImportHandler struct {
+	importService *services.MoodImportService
+}
+
+func NewMoodImportHandler(importService *services.MoodImportService) *MoodImportHandler {
+	return &MoodImportHandler{importService: importService}
+}
+
+// ImportMoods handles POST /api/imports/mood?format=daylio|bearable|csv&dry_run=true
+// (multipart form, field "file")
+func (h *MoodImportHandler) ImportMoods(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "A file upload is required",
+		})
+	}
+
+	result, err := h.importService.Import(userID, file, c.Query("format"), c.QueryBool("dry_run"))
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownImportFormat) || errors.Is(err, services.ErrInvalidMoodImport) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to import check-ins",
+		})
+	}
+
+	if result.DryRun {
+		return c.JSON(result)
+	}
+	return c.Status(fiber.StatusCreated).JSON(result)
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"errors"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PhotoHandler struct {
+	photoService *services.PhotoService
+	storage      services.ObjectStorage
+}
+
+func NewPhotoHandler(photoService *services.PhotoService, storage services.ObjectStorage) *PhotoHandler {
+	return &PhotoHandler{photoService: photoService, storage: storage}
+}
+
+// AddPhoto handles POST /api/feels/:id/photos (multipart form, field "photo")
+func (h *PhotoHandler) AddPhoto(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	checkID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid check-in ID",
+		})
+	}
+
+	file, err := c.FormFile("photo")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "A photo upload is required",
+		})
+	}
+
+	photo, err := h.photoService.AddPhoto(userID, checkID, file)
+	if err != nil {
+		if errors.Is(err, services.ErrPhotoStorage) {
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error: true, Message: "Failed to store photo",
+			})
+		}
+		return photoError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(photo)
+}
+
+// ListPhotos handles GET /api/feels/:id/photos
+func (h *PhotoHandler) ListPhotos(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	checkID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid check-in ID",
+		})
+	}
+
+	photos, err := h.photoService.ListPhotos(userID, checkID)
+	if err != nil {
+		if errors.Is(err, services.ErrFeelCheckNotFound) {
+			return photoError(c, err)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch photos",
+		})
+	}
+
+	return c.JSON(fiber.Map{"data": photos})
+}
+
+// DeletePhoto handles DELETE /api/feels/:id/photos/:photoId
+func (h *PhotoHandler) DeletePhoto(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	checkID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid check-in ID",
+		})
+	}
+	photoID, err := uuid.Parse(c.Params("photoId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid photo ID",
+		})
+	}
+
+	if err := h.photoService.DeletePhoto(userID, checkID, photoID); err != nil {
+		if errors.Is(err, services.ErrPhotoNotFound) {
+			return photoError(c, err)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to delete photo",
+		})
+	}
+
+	return c.JSON(fiber.Map{"message": "Photo deleted successfully"})
+}
+
+// GetMedia handles GET /api/media/* for the local storage backend. The signed
+// query string is the only authorization, so the URL works in image views.
+func (h *PhotoHandler) GetMedia(c *fiber.Ctx) error {
+	local, ok := h.storage.(*services.LocalStorage)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+			Error: true, Message: "Not found",
+		})
+	}
+
+	f, expiresAt, err := local.Open(c.Params("*"), c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidMediaSignature) {
+			return c.Status(fiber.StatusForbidden).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrObjectNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: "Not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to read media",
+		})
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to read media",
+		})
+	}
+
+	// Photos never change under a key, so they can be cached until the URL expires
+	maxAge := int(time.Until(expiresAt).Seconds())
+	c.Set(fiber.HeaderCacheControl, "private, max-age="+strconv.Itoa(maxAge))
+	c.Set("X-Content-Type-Options", "nosniff")
+	c.Type(path.Ext(c.Params("*")))
+	// Fiber closes the file once the response is written
+	return c.SendStream(f, int(info.Size()))
+}
+
+func photoError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrFeelCheckNotFound) || errors.Is(err, services.ErrPhotoNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+		Error: true, Message: err.Error(),
+	})
+}
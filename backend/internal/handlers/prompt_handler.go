@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PromptHandler struct {
+	promptService *services.PromptService
+}
+
+func NewPromptHandler(promptService *services.PromptService) *PromptHandler {
+	return &PromptHandler{promptService: promptService}
+}
+
+// GetDailyPrompt handles GET /api/feels/prompt?locale=
+func (h *PromptHandler) GetDailyPrompt(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	locale := c.Query("locale", c.Get(fiber.HeaderAcceptLanguage))
+	prompt, err := h.promptService.GetDailyPrompt(userID, locale)
+	if err != nil {
+		if errors.Is(err, services.ErrNoPrompts) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch prompt",
+		})
+	}
+
+	return c.JSON(prompt)
+}
+
+// ListPrompts handles GET /api/admin/prompts?locale=&category=
+func (h *PromptHandler) ListPrompts(c *fiber.Ctx) error {
+	var query dto.PromptListQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid query parameters",
+		})
+	}
+
+	prompts, err := h.promptService.ListPrompts(&query)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch prompts",
+		})
+	}
+
+	return c.JSON(fiber.Map{"data": prompts})
+}
+
+// CreatePrompt handles POST /api/admin/prompts
+func (h *PromptHandler) CreatePrompt(c *fiber.Ctx) error {
+	var req dto.CreatePromptRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	prompt, err := h.promptService.CreatePrompt(&req)
+	if err != nil {
+		return promptError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(prompt)
+}
+
+// UpdatePrompt handles PUT /api/admin/prompts/:id
+func (h *PromptHandler) UpdatePrompt(c *fiber.Ctx) error {
+	promptID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid prompt ID",
+		})
+	}
+
+	var req dto.UpdatePromptRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	prompt, err := h.promptService.UpdatePrompt(promptID, &req)
+	if err != nil {
+		return promptError(c, err)
+	}
+
+	return c.JSON(prompt)
+}
+
+// DeletePrompt handles DELETE /api/admin/prompts/:id
+func (h *PromptHandler) DeletePrompt(c *fiber.Ctx) error {
+	promptID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid prompt ID",
+		})
+	}
+
+	if err := h.promptService.DeletePrompt(promptID); err != nil {
+		return promptError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"message": "Prompt deleted successfully"})
+}
+
+func promptError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrPromptNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+		Error: true, Message: err.Error(),
+	})
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type QuestionnaireHandler struct {
+	questionnaireService *services.QuestionnaireService
+}
+
+func NewQuestionnaireHandler(questionnaireService *services.QuestionnaireService) *QuestionnaireHandler {
+	return &QuestionnaireHandler{questionnaireService: questionnaireService}
+}
+
+// ListQuestionnaires handles GET /api/questionnaires
+func (h *QuestionnaireHandler) ListQuestionnaires(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	questionnaires, err := h.questionnaireService.ListQuestionnaires(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch questionnaires",
+		})
+	}
+
+	return c.JSON(fiber.Map{"data": questionnaires})
+}
+
+// GetQuestionnaire handles GET /api/questionnaires/:id
+func (h *QuestionnaireHandler) GetQuestionnaire(c *fiber.Ctx) error {
+	def, err := h.questionnaireService.GetDefinition(c.Params("id"))
+	if err != nil {
+		return questionnaireError(c, err)
+	}
+
+	return c.JSON(def)
+}
+
+// SubmitResult handles POST /api/questionnaires/:id/results
+func (h *QuestionnaireHandler) SubmitResult(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var req dto.SubmitQuestionnaireRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	locale := c.Query("locale", c.Get(fiber.HeaderAcceptLanguage))
+	result, err := h.questionnaireService.Submit(userID, c.Params("id"), &req, locale)
+	if err != nil {
+		return questionnaireError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(result)
+}
+
+// ListResults handles GET /api/questionnaires/:id/results
+func (h *QuestionnaireHandler) ListResults(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	results, err := h.questionnaireService.ListResults(userID, c.Params("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrQuestionnaireNotFound) {
+			return questionnaireError(c, err)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch results",
+		})
+	}
+
+	return c.JSON(fiber.Map{"data": results})
+}
+
+// GetTrend handles GET /api/questionnaires/:id/trend?range=30d|90d|1y
+func (h *QuestionnaireHandler) GetTrend(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	trend, err := h.questionnaireService.GetTrend(userID, c.Params("id"), c.Query("range", "1y"))
+	if err != nil {
+		if errors.Is(err, services.ErrQuestionnaireNotFound) || errors.Is(err, services.ErrInvalidInsightRange) {
+			return questionnaireError(c, err)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch trend",
+		})
+	}
+
+	return c.JSON(trend)
+}
+
+// UpdateSchedule handles PUT /api/questionnaires/:id/schedule
+func (h *QuestionnaireHandler) UpdateSchedule(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var req dto.UpdateQuestionnaireScheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	schedule, err := h.questionnaireService.UpdateSchedule(userID, c.Params("id"), &req)
+	if err != nil {
+		return questionnaireError(c, err)
+	}
+
+	return c.JSON(schedule)
+}
+
+func questionnaireError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrQuestionnaireNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+		Error: true, Message: err.Error(),
+	})
+}
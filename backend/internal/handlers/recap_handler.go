@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type RecapHandler struct {
+	recapService *services.RecapService
+}
+
+func NewRecapHandler(recapService *services.RecapService) *RecapHandler {
+	return &RecapHandler{recapService: recapService}
+}
+
+// GetRecaps handles GET /api/feels/recaps?type=week|month&limit=
+func (h *RecapHandler) GetRecaps(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var req dto.RecapQuery
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid query parameters",
+		})
+	}
+
+	recaps, err := h.recapService.ListRecaps(userID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRecapType) || errors.Is(err, services.ErrInvalidRecapLimit) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch recaps",
+		})
+	}
+
+	return c.JSON(recaps)
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ShareHandler struct {
+	shareService *services.ShareService
+}
+
+func NewShareHandler(shareService *services.ShareService) *ShareHandler {
+	return &ShareHandler{shareService: shareService}
+}
+
+// ListGrants handles GET /api/shares
+func (h *ShareHandler) ListGrants(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	grants, err := h.shareService.ListGrants(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch share links",
+		})
+	}
+
+	return c.JSON(fiber.Map{"data": grants})
+}
+
+// CreateGrant handles POST /api/shares
+func (h *ShareHandler) CreateGrant(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var req dto.CreateShareGrantRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	grant, err := h.shareService.CreateGrant(userID, &req, c.BaseURL())
+	if err != nil {
+		if errors.Is(err, services.ErrTooManyShareGrants) {
+			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(grant)
+}
+
+// RevokeGrant handles DELETE /api/shares/:id
+func (h *ShareHandler) RevokeGrant(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	grantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid share link ID",
+		})
+	}
+
+	if err := h.shareService.RevokeGrant(userID, grantID); err != nil {
+		if errors.Is(err, services.ErrShareGrantNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to revoke share link",
+		})
+	}
+
+	return c.JSON(fiber.Map{"message": "Share link revoked successfully"})
+}
+
+// ListAccesses handles GET /api/shares/:id/accesses
+func (h *ShareHandler) ListAccesses(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	grantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid share link ID",
+		})
+	}
+
+	accesses, err := h.shareService.ListAccesses(userID, grantID)
+	if err != nil {
+		if errors.Is(err, services.ErrShareGrantNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch access log",
+		})
+	}
+
+	return c.JSON(fiber.Map{"data": accesses})
+}
+
+// GetSharedView handles GET /api/shared/:token (public, authorized by the token)
+func (h *ShareHandler) GetSharedView(c *fiber.Ctx) error {
+	grant, err := h.openGrant(c, services.ShareResourceGrant)
+	if err != nil {
+		return shareTokenError(c, err)
+	}
+
+	return c.JSON(h.shareService.SharedView(grant))
+}
+
+// GetSharedHistory handles GET /api/shared/:token/history (same query as /api/feels/history)
+func (h *ShareHandler) GetSharedHistory(c *fiber.Ctx) error {
+	var req dto.FeelHistoryQuery
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid query parameters",
+		})
+	}
+
+	grant, err := h.openGrant(c, services.ShareResourceHistory)
+	if err != nil {
+		return shareTokenError(c, err)
+	}
+
+	opts, err := h.shareService.ParseSharedHistoryQuery(grant, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+
+	history, err := h.shareService.SharedHistory(grant, opts)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch history",
+		})
+	}
+
+	return c.JSON(history)
+}
+
+// GetSharedInsights handles GET /api/shared/:token/insights
+func (h *ShareHandler) GetSharedInsights(c *fiber.Ctx) error {
+	grant, err := h.openGrant(c, services.ShareResourceInsights)
+	if err != nil {
+		return shareTokenError(c, err)
+	}
+
+	insights, err := h.shareService.SharedInsights(grant)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch insights",
+		})
+	}
+
+	return c.JSON(insights)
+}
+
+func (h *ShareHandler) openGrant(c *fiber.Ctx, resource string) (*models.ShareGrant, error) {
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Set("Referrer-Policy", "no-referrer")
+	return h.shareService.OpenGrant(c.Params("token"), resource, c.IP(), c.Get(fiber.HeaderUserAgent))
+}
+
+func shareTokenError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrShareGrantNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrShareGrantInactive):
+		return c.Status(fiber.StatusGone).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+		Error: true, Message: "Failed to open share link",
+	})
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TagHandler struct {
+	tagService *services.TagService
+}
+
+func NewTagHandler(tagService *services.TagService) *TagHandler {
+	return &TagHandler{tagService: tagService}
+}
+
+// ListTags handles GET /api/tags
+func (h *TagHandler) ListTags(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	tags, err := h.tagService.ListTags(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch tags",
+		})
+	}
+
+	return c.JSON(fiber.Map{"data": tags})
+}
+
+// CreateTag handles POST /api/tags
+func (h *TagHandler) CreateTag(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var req dto.CreateTagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	tag, err := h.tagService.CreateTag(userID, &req)
+	if err != nil {
+		return tagError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(tag)
+}
+
+// UpdateTag handles PUT /api/tags/:id
+func (h *TagHandler) UpdateTag(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	tagID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid tag ID",
+		})
+	}
+
+	var req dto.UpdateTagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	tag, err := h.tagService.UpdateTag(userID, tagID, &req)
+	if err != nil {
+		return tagError(c, err)
+	}
+
+	return c.JSON(tag)
+}
+
+// DeleteTag handles DELETE /api/tags/:id
+func (h *TagHandler) DeleteTag(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	tagID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid tag ID",
+		})
+	}
+
+	if err := h.tagService.DeleteTag(userID, tagID); err != nil {
+		return tagError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"message": "Tag deleted successfully"})
+}
+
+// tagError maps tag service errors to HTTP responses
+func tagError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrTagNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrTagExists):
+		return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrDefaultTagReadOnly):
+		return c.Status(fiber.StatusForbidden).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+}
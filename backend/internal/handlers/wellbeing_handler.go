@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type WellbeingHandler struct {
+	wellbeingService *services.WellbeingService
+}
+
+func NewWellbeingHandler(wellbeingService *services.WellbeingService) *WellbeingHandler {
+	return &WellbeingHandler{wellbeingService: wellbeingService}
+}
+
+// GetWellbeing handles GET /api/feels/wellbeing?locale=
+func (h *WellbeingHandler) GetWellbeing(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	locale := c.Query("locale", c.Get(fiber.HeaderAcceptLanguage))
+	resp, err := h.wellbeingService.GetWellbeing(userID, locale)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch wellbeing alerts",
+		})
+	}
+
+	return c.JSON(resp)
+}
+
+// DismissAlert handles POST /api/feels/wellbeing/:id/dismiss
+func (h *WellbeingHandler) DismissAlert(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	alertID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid alert ID",
+		})
+	}
+
+	if err := h.wellbeingService.DismissAlert(userID, alertID); err != nil {
+		if errors.Is(err, services.ErrAlertNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: "Alert not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to dismiss alert",
+		})
+	}
+
+	return c.JSON(fiber.Map{"message": "Alert dismissed"})
+}
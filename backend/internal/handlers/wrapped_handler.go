@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type WrappedHandler struct {
+	wrappedService *services.WrappedService
+}
+
+func NewWrappedHandler(wrappedService *services.WrappedService) *WrappedHandler {
+	return &WrappedHandler{wrappedService: wrappedService}
+}
+
+// GetWrapped handles GET /api/feels/wrapped?year=
+func (h *WrappedHandler) GetWrapped(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	summary, err := h.wrappedService.GetWrapped(userID, c.QueryInt("year", 0))
+	if err != nil {
+		if errors.Is(err, services.ErrWrappedNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: "Wrapped is not available yet",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch wrapped",
+		})
+	}
+
+	return c.JSON(summary)
+}
+
+// PreviewWrapped handles POST /api/admin/wrapped/:userId/preview?year=
+// Regenerates the summary from live data without storing it.
+func (h *WrappedHandler) PreviewWrapped(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid user ID",
+		})
+	}
+
+	doc, err := h.wrappedService.PreviewWrapped(userID, c.QueryInt("year", time.Now().UTC().Year()))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidWrappedYear) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrUserNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: "User not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to generate wrapped",
+		})
+	}
+
+	return c.JSON(doc)
+}
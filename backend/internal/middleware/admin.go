@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// RequireAdmin rejects users without the admin role. It must run after JWTProtected.
+func RequireAdmin(auth *services.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, ok := tokenUser(c)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+				Error:   true,
+				Message: "Unauthorized",
+			})
+		}
+
+		admin, err := auth.IsAdmin(userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error:   true,
+				Message: "Failed to check permissions",
+			})
+		}
+		if !admin {
+			return c.Status(fiber.StatusForbidden).JSON(dto.ErrorResponse{
+				Error:   true,
+				Message: "Admin access required",
+			})
+		}
+		return c.Next()
+	}
+}
+
+// tokenUser returns the user ID from the JWT set by JWTProtected
+func tokenUser(c *fiber.Ctx) (uuid.UUID, bool) {
+	token, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return uuid.Nil, false
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return uuid.Nil, false
+	}
+	sub, ok := claims["sub"].(string)
+	if !ok {
+		return uuid.Nil, false
+	}
+	userID, err := uuid.Parse(sub)
+	return userID, err == nil
+}
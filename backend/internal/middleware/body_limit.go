@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"io"
+	"strings"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimit rejects request bodies larger than limit bytes, replacing Fiber's
+// check now that bodies are streamed. Paths in overrides get their own limit
+// and stay streamed for the handler, so they must send Content-Length.
+func BodyLimit(limit int, overrides map[string]int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		max, override := overrideLimit(overrides, c.Path())
+		if !override {
+			max = limit
+		}
+
+		length := c.Request().Header.ContentLength()
+		if length > max {
+			return tooLarge(c)
+		}
+
+		if override {
+			if length == -1 {
+				// Unread bodies can't be skipped, so don't reuse the connection
+				c.Context().SetConnectionClose()
+				return c.Status(fiber.StatusLengthRequired).JSON(dto.ErrorResponse{
+					Error:   true,
+					Message: "Content-Length is required",
+				})
+			}
+			// The handler may stop before reading the whole upload
+			c.Context().SetConnectionClose()
+			return c.Next()
+		}
+
+		// Chunked bodies have no length up front, so cap the read
+		if length != 0 && c.Request().IsBodyStream() {
+			body, err := io.ReadAll(io.LimitReader(c.Context().RequestBodyStream(), int64(max)+1))
+			if err != nil {
+				c.Context().SetConnectionClose()
+				return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+					Error:   true,
+					Message: "Invalid request body",
+				})
+			}
+			if len(body) > max {
+				return tooLarge(c)
+			}
+			c.Request().SetBody(body)
+		}
+
+		return c.Next()
+	}
+}
+
+// overrideLimit finds the override for path, matching :param segments of
+// patterns such as /api/feels/:id/photos against any single segment
+func overrideLimit(overrides map[string]int, path string) (int, bool) {
+	if max, ok := overrides[path]; ok {
+		return max, true
+	}
+	segments := strings.Split(path, "/")
+	for pattern, max := range overrides {
+		if !strings.Contains(pattern, ":") {
+			continue
+		}
+		parts := strings.Split(pattern, "/")
+		if len(parts) != len(segments) {
+			continue
+		}
+		match := true
+		for i, part := range parts {
+			if part != segments[i] && (!strings.HasPrefix(part, ":") || segments[i] == "") {
+				match = false
+				break
+			}
+		}
+		if match {
+			return max, true
+		}
+	}
+	return 0, false
+}
+
+func tooLarge(c *fiber.Ctx) error {
+	c.Context().SetConnectionClose()
+	return c.Status(fiber.StatusRequestEntityTooLarge).JSON(dto.ErrorResponse{
+		Error:   true,
+		Message: "Request body too large",
+	})
+}
@@ -9,7 +9,8 @@ import (
 func CORS(cfg *config.Config) fiber.Handler {
 	return cors.New(cors.Config{
 		AllowOrigins:     cfg.CORSOrigins,
-		AllowHeaders:     "Origin, Content-Type, Authorization, Accept",
+		AllowHeaders:     "Origin, Content-Type, Authorization, Accept, Idempotency-Key",
+		ExposeHeaders:    "Idempotent-Replayed",
 		AllowMethods:     "GET, POST, PUT, DELETE, PATCH, OPTIONS",
 		AllowCredentials: false,
 	})
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// Idempotency replays the stored response when a POST, PUT or DELETE is retried
+// with the same Idempotency-Key header. Keys are scoped to the authenticated user,
+// so it must run after JWTProtected. Stored responses are encrypted per user.
+// Server errors are not stored, so the retry runs the request again.
+func Idempotency(svc *services.IdempotencyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodDelete:
+		default:
+			return c.Next()
+		}
+
+		key := c.Get("Idempotency-Key")
+		if key == "" {
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error:   true,
+				Message: "Idempotency-Key must be at most 255 characters",
+			})
+		}
+
+		userID, ok := tokenUser(c)
+		if !ok {
+			return c.Next()
+		}
+
+		hash := services.HashRequest(c.Method(), c.OriginalURL(), c.Body())
+		stored, err := svc.Begin(c.UserContext(), userID, key, hash)
+		switch {
+		case errors.Is(err, services.ErrIdempotencyKeyReused):
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(dto.ErrorResponse{
+				Error:   true,
+				Message: err.Error(),
+			})
+		case errors.Is(err, services.ErrIdempotencyKeyInProgress):
+			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+				Error:   true,
+				Message: err.Error(),
+			})
+		case err != nil:
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error:   true,
+				Message: "Failed to process Idempotency-Key",
+			})
+		}
+
+		if stored != nil {
+			c.Set("Idempotent-Replayed", "true")
+			if stored.ContentType != "" {
+				c.Set(fiber.HeaderContentType, stored.ContentType)
+			}
+			return c.Status(stored.StatusCode).Send(stored.Response)
+		}
+
+		if err := c.Next(); err != nil {
+			if releaseErr := svc.Release(userID, key); releaseErr != nil {
+				log.Printf("Failed to release idempotency key for user %s: %v", userID, releaseErr)
+			}
+			return err
+		}
+
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			if err := svc.Release(userID, key); err != nil {
+				log.Printf("Failed to release idempotency key for user %s: %v", userID, err)
+			}
+			return nil
+		}
+
+		// Fiber reuses the response buffer, so store a copy
+		body := append([]byte(nil), c.Response().Body()...)
+		contentType := string(c.Response().Header.ContentType())
+		if err := svc.Complete(userID, key, status, contentType, body); err != nil {
+			log.Printf("Failed to store idempotent response for user %s: %v", userID, err)
+		}
+		return nil
+	}
+}
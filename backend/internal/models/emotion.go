@@ -0,0 +1,86 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Mood-meter quadrants (valence x arousal)
+const (
+	QuadrantHighPleasant   = "high_pleasant"   // Yellow: excited, joyful
+	QuadrantHighUnpleasant = "high_unpleasant" // Red: angry, anxious
+	QuadrantLowUnpleasant  = "low_unpleasant"  // Blue: sad, tired
+	QuadrantLowPleasant    = "low_pleasant"    // Green: calm, content
+)
+
+// Emotion is an entry in the server-side emotion catalog
+type Emotion struct {
+	ID           string            `json:"id"`
+	Quadrant     string            `json:"quadrant"`
+	Valence      float64           `json:"valence"` // -1 (unpleasant) to 1 (pleasant)
+	Arousal      float64           `json:"arousal"` // -1 (low energy) to 1 (high energy)
+	DefaultEmoji string            `json:"default_emoji"`
+	Labels       map[string]string `json:"-"` // Locale -> label
+}
+
+// Label returns the emotion label for a locale such as "tr" or "tr-TR", falling back to English
+func (e Emotion) Label(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_,;"); i > 0 {
+		locale = locale[:i]
+	}
+	if label, ok := e.Labels[locale]; ok {
+		return label
+	}
+	return e.Labels["en"]
+}
+
+// EmotionCatalog lists the selectable emotions, grouped by quadrant
+var EmotionCatalog = []Emotion{
+	{ID: "excited", Quadrant: QuadrantHighPleasant, Valence: 0.7, Arousal: 0.8, DefaultEmoji: "🤩", Labels: map[string]string{"en": "Excited", "tr": "Heyecanlı", "es": "Emocionado"}},
+	{ID: "joyful", Quadrant: QuadrantHighPleasant, Valence: 0.8, Arousal: 0.6, DefaultEmoji: "😄", Labels: map[string]string{"en": "Joyful", "tr": "Neşeli", "es": "Alegre"}},
+	{ID: "proud", Quadrant: QuadrantHighPleasant, Valence: 0.7, Arousal: 0.5, DefaultEmoji: "😎", Labels: map[string]string{"en": "Proud", "tr": "Gururlu", "es": "Orgulloso"}},
+	{ID: "determined", Quadrant: QuadrantHighPleasant, Valence: 0.4, Arousal: 0.7, DefaultEmoji: "💪", Labels: map[string]string{"en": "Determined", "tr": "Kararlı", "es": "Decidido"}},
+	{ID: "hopeful", Quadrant: QuadrantHighPleasant, Valence: 0.6, Arousal: 0.3, DefaultEmoji: "🌈", Labels: map[string]string{"en": "Hopeful", "tr": "Umutlu", "es": "Esperanzado"}},
+
+	{ID: "angry", Quadrant: QuadrantHighUnpleasant, Valence: -0.7, Arousal: 0.8, DefaultEmoji: "😠", Labels: map[string]string{"en": "Angry", "tr": "Öfkeli", "es": "Enojado"}},
+	{ID: "anxious", Quadrant: QuadrantHighUnpleasant, Valence: -0.6, Arousal: 0.7, DefaultEmoji: "😰", Labels: map[string]string{"en": "Anxious", "tr": "Kaygılı", "es": "Ansioso"}},
+	{ID: "stressed", Quadrant: QuadrantHighUnpleasant, Valence: -0.6, Arousal: 0.6, DefaultEmoji: "😫", Labels: map[string]string{"en": "Stressed", "tr": "Stresli", "es": "Estresado"}},
+	{ID: "frustrated", Quadrant: QuadrantHighUnpleasant, Valence: -0.6, Arousal: 0.5, DefaultEmoji: "😤", Labels: map[string]string{"en": "Frustrated", "tr": "Hayal kırıklığına uğramış", "es": "Frustrado"}},
+	{ID: "overwhelmed", Quadrant: QuadrantHighUnpleasant, Valence: -0.7, Arousal: 0.7, DefaultEmoji: "🤯", Labels: map[string]string{"en": "Overwhelmed", "tr": "Bunalmış", "es": "Abrumado"}},
+
+	{ID: "sad", Quadrant: QuadrantLowUnpleasant, Valence: -0.7, Arousal: -0.4, DefaultEmoji: "😢", Labels: map[string]string{"en": "Sad", "tr": "Üzgün", "es": "Triste"}},
+	{ID: "lonely", Quadrant: QuadrantLowUnpleasant, Valence: -0.6, Arousal: -0.5, DefaultEmoji: "🥺", Labels: map[string]string{"en": "Lonely", "tr": "Yalnız", "es": "Solo"}},
+	{ID: "tired", Quadrant: QuadrantLowUnpleasant, Valence: -0.3, Arousal: -0.8, DefaultEmoji: "😴", Labels: map[string]string{"en": "Tired", "tr": "Yorgun", "es": "Cansado"}},
+	{ID: "bored", Quadrant: QuadrantLowUnpleasant, Valence: -0.3, Arousal: -0.6, DefaultEmoji: "😐", Labels: map[string]string{"en": "Bored", "tr": "Sıkılmış", "es": "Aburrido"}},
+	{ID: "disappointed", Quadrant: QuadrantLowUnpleasant, Valence: -0.6, Arousal: -0.3, DefaultEmoji: "😞", Labels: map[string]string{"en": "Disappointed", "tr": "Hayal kırıklığı", "es": "Decepcionado"}},
+
+	{ID: "calm", Quadrant: QuadrantLowPleasant, Valence: 0.6, Arousal: -0.5, DefaultEmoji: "😌", Labels: map[string]string{"en": "Calm", "tr": "Sakin", "es": "Tranquilo"}},
+	{ID: "content", Quadrant: QuadrantLowPleasant, Valence: 0.7, Arousal: -0.3, DefaultEmoji: "🙂", Labels: map[string]string{"en": "Content", "tr": "Memnun", "es": "Satisfecho"}},
+	{ID: "relaxed", Quadrant: QuadrantLowPleasant, Valence: 0.6, Arousal: -0.6, DefaultEmoji: "😊", Labels: map[string]string{"en": "Relaxed", "tr": "Rahat", "es": "Relajado"}},
+	{ID: "grateful", Quadrant: QuadrantLowPleasant, Valence: 0.8, Arousal: -0.2, DefaultEmoji: "🙏", Labels: map[string]string{"en": "Grateful", "tr": "Minnettar", "es": "Agradecido"}},
+	{ID: "peaceful", Quadrant: QuadrantLowPleasant, Valence: 0.7, Arousal: -0.7, DefaultEmoji: "🕊️", Labels: map[string]string{"en": "Peaceful", "tr": "Huzurlu", "es": "En paz"}},
+}
+
+var emotionsByID = func() map[string]Emotion {
+	m := make(map[string]Emotion, len(EmotionCatalog))
+	for _, e := range EmotionCatalog {
+		m[e.ID] = e
+	}
+	return m
+}()
+
+// FindEmotion looks up a catalog emotion by ID
+func FindEmotion(id string) (Emotion, bool) {
+	e, ok := emotionsByID[id]
+	return e, ok
+}
+
+// FeelCheckEmotion links a check-in to a catalog emotion
+type FeelCheckEmotion struct {
+	FeelCheckID uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	EmotionID   string    `gorm:"size:30;primaryKey;index" json:"emotion_id"`
+	CreatedAt   time.Time `json:"-"`
+}
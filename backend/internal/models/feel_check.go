@@ -9,43 +9,40 @@ import (
 
 // FeelCheck represents a daily mood/energy check-in
 type FeelCheck struct {
-	ID          uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	UserID      uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
-	MoodScore   int            `gorm:"not null" json:"mood_score"`   // 1-100
-	EnergyScore int            `gorm:"not null" json:"energy_score"` // 1-100
-	FeelScore   int            `gorm:"not null" json:"feel_score"`   // Combined score 1-100
-	MoodEmoji   string         `gorm:"size:10" json:"mood_emoji"`    // 😊 😢 😤 etc.
-	Note        string         `gorm:"size:280" json:"note"`         // Optional note
-	ColorHex    string         `gorm:"size:7" json:"color_hex"`      // Gradient color based on score
-	CheckDate   time.Time      `gorm:"type:date;not null;index" json:"check_date"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID            uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID        uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	MoodScore     int            `gorm:"not null" json:"mood_score"`              // 1-100
+	EnergyScore   int            `gorm:"not null" json:"energy_score"`            // 1-100
+	FeelScore     int            `gorm:"not null" json:"feel_score"`              // Combined score 1-100
+	MoodEmoji     string         `gorm:"size:10" json:"mood_emoji"`               // 😊 😢 😤 etc.
+	Note          string         `gorm:"-" json:"note"`                           // Optional note, decrypted on read
+	EncryptedNote []byte         `gorm:"type:bytea" json:"-"`                     // AES-GCM encrypted note
+	LegacyNote    string         `gorm:"column:note;size:280" json:"-"`           // Pre-encryption plaintext, emptied by backfill
+	ColorHex      string         `gorm:"size:7" json:"color_hex"`                 // Gradient color based on score
+	ScoreVersion  int            `gorm:"not null;default:1" json:"score_version"` // Formula version that produced FeelScore
+	CheckDate     time.Time      `gorm:"type:date;not null;index" json:"check_date"`
+	EditedAt      *time.Time     `json:"edited_at,omitempty"`                        // Last edit, client time for synced edits
+	PromptID      *uuid.UUID     `gorm:"type:uuid;index" json:"prompt_id,omitempty"` // Reflection prompt the note answers
+	SyncVersion   int64          `gorm:"not null;default:0;index" json:"-"`          // Bumped by trigger on every write
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
 
-	User User `gorm:"foreignKey:UserID" json:"-"`
+	User     User               `gorm:"foreignKey:UserID" json:"-"`
+	Tags     []Tag              `gorm:"many2many:feel_check_tags;" json:"tags,omitempty"`
+	Emotions []FeelCheckEmotion `gorm:"foreignKey:FeelCheckID" json:"emotions,omitempty"`
 }
 
-// CalculateFeelScore computes the combined feel score
+// CalculateFeelScore computes the combined feel score with the active formula
 func (f *FeelCheck) CalculateFeelScore() {
-	f.FeelScore = (f.MoodScore + f.EnergyScore) / 2
+	formula := ActiveScoreFormula()
+	f.ScoreVersion = formula.Version
+	f.FeelScore = formula.Score(f.MoodScore, f.EnergyScore)
 }
 
-// GetColorHex returns a color based on the feel score
+// GetColorHex returns a color based on the feel score and the formula that produced it
 func (f *FeelCheck) GetColorHex() string {
-	switch {
-	case f.FeelScore >= 90:
-		return "#22c55e" // Green - Amazing
-	case f.FeelScore >= 75:
-		return "#84cc16" // Lime - Great
-	case f.FeelScore >= 60:
-		return "#eab308" // Yellow - Good
-	case f.FeelScore >= 45:
-		return "#f97316" // Orange - Okay
-	case f.FeelScore >= 30:
-		return "#ef4444" // Red - Not great
-	default:
-		return "#8b5cf6" // Purple - Low
-	}
+	return ScoreFormulaFor(f.ScoreVersion).Band(f.FeelScore).Color
 }
 
 // FeelStreak tracks daily check-in streaks
@@ -65,6 +62,16 @@ type FeelStreak struct {
 	User User `gorm:"foreignKey:UserID" json:"-"`
 }
 
+// BadgeUnlock records when a badge was unlocked
+type BadgeUnlock struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_badge_unlock_user_badge" json:"user_id"`
+	Badge      string    `gorm:"size:50;not null;uniqueIndex:idx_badge_unlock_user_badge" json:"badge"`
+	UnlockedAt time.Time `gorm:"not null;index" json:"unlocked_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
 // FeelFriend represents friend connections for comparing feels
 type FeelFriend struct {
 	ID        uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
@@ -84,7 +91,7 @@ type GoodVibe struct {
 	ID         uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
 	SenderID   uuid.UUID      `gorm:"type:uuid;not null;index" json:"sender_id"`
 	ReceiverID uuid.UUID      `gorm:"type:uuid;not null;index" json:"receiver_id"`
-	Message    string         `gorm:"size:100" json:"message"` // Short positive message
+	Message    string         `gorm:"size:100" json:"message"`  // Short positive message
 	VibeType   string         `gorm:"size:20" json:"vibe_type"` // hug, high-five, sunshine, etc.
 	CreatedAt  time.Time      `json:"created_at"`
 	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
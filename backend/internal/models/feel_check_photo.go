@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeelCheckPhoto is an image attached to a check-in. The files live in object
+// storage under Key and ThumbKey; clients only ever get signed URLs.
+type FeelCheckPhoto struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	FeelCheckID uuid.UUID `gorm:"type:uuid;not null;index" json:"feel_check_id"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Key         string    `gorm:"not null" json:"-"`
+	ThumbKey    string    `gorm:"not null" json:"-"`
+	ContentType string    `gorm:"size:32;not null" json:"content_type"`
+	Width       int       `gorm:"not null" json:"width"`
+	Height      int       `gorm:"not null" json:"height"`
+	Size        int       `gorm:"not null" json:"size"` // Bytes of the stored image
+	CreatedAt   time.Time `json:"created_at"`
+
+	FeelCheck FeelCheck `gorm:"foreignKey:FeelCheckID" json:"-"`
+	User      User      `gorm:"foreignKey:UserID" json:"-"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ForecastMethod identifies the model that produced a forecast, so accuracy can
+// be compared when the model changes
+const ForecastMethod = "ses-dow-v1"
+
+// MoodForecast is a stored next-day forecast. Actual scores are filled in once the
+// user checks in on the forecast date, so accuracy can be evaluated.
+type MoodForecast struct {
+	ID                uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID            uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_forecast_user_date" json:"user_id"`
+	ForecastDate      time.Time `gorm:"type:date;not null;uniqueIndex:idx_forecast_user_date" json:"forecast_date"`
+	Method            string    `gorm:"size:20;not null" json:"method"`
+	HistorySize       int       `gorm:"not null" json:"history_size"` // Check-ins the forecast was based on
+	FeelScore         float64   `gorm:"not null" json:"feel_score"`
+	FeelLow           float64   `gorm:"not null" json:"feel_low"`
+	FeelHigh          float64   `gorm:"not null" json:"feel_high"`
+	EnergyScore       float64   `gorm:"not null" json:"energy_score"`
+	EnergyLow         float64   `gorm:"not null" json:"energy_low"`
+	EnergyHigh        float64   `gorm:"not null" json:"energy_high"`
+	ActualFeelScore   *int      `json:"actual_feel_score"`
+	ActualEnergyScore *int      `json:"actual_energy_score"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Goal types
+const (
+	GoalCheckInDays = "check_in_days" // Check in on at least Target days in the period
+	GoalAvgScore    = "avg_score"     // Average feel score of at least Target in the period
+	GoalStreakDays  = "streak_days"   // A run of Target consecutive check-in days in the period
+)
+
+// Goal periods
+const (
+	GoalWeekly  = "week"
+	GoalMonthly = "month"
+)
+
+// Goal is a personal check-in target that repeats every period
+type Goal struct {
+	ID          uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID      uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	Type        string         `gorm:"size:30;not null" json:"type"`
+	Target      int            `gorm:"not null" json:"target"`
+	Period      string         `gorm:"size:10;not null" json:"period"` // week, month
+	Title       string         `gorm:"size:100" json:"title"`
+	IsActive    bool           `gorm:"not null;default:true" json:"is_active"`
+	Progress    float64        `gorm:"not null;default:0" json:"progress"` // Value for PeriodStart's period
+	PeriodStart *time.Time     `gorm:"type:date" json:"period_start"`      // Period Progress was last evaluated for
+	CompletedAt *time.Time     `json:"completed_at"`                       // Set when PeriodStart's period was completed
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// GoalCompletion records a goal met for one period
+type GoalCompletion struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	GoalID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_goal_completion_period" json:"goal_id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	GoalType    string     `gorm:"size:30;not null" json:"goal_type"`
+	Target      int        `gorm:"not null" json:"target"`
+	Value       float64    `gorm:"not null" json:"value"`
+	PeriodStart time.Time  `gorm:"type:date;not null;uniqueIndex:idx_goal_completion_period" json:"period_start"`
+	PeriodEnd   time.Time  `gorm:"type:date;not null" json:"period_end"`
+	CompletedAt time.Time  `gorm:"not null" json:"completed_at"`
+	SeenAt      *time.Time `json:"seen_at"` // Nil until shown to the user
+
+	Goal Goal `gorm:"foreignKey:GoalID" json:"-"`
+}
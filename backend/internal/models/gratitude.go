@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxGratitudeItems is how many things a user can be grateful for per day
+const MaxGratitudeItems = 3
+
+// GratitudeEntry is a user's gratitude list for one day, kept apart from the
+// mood score. Items are encrypted with the user's note key like check-in notes.
+type GratitudeEntry struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_gratitude_user_date" json:"user_id"`
+	EntryDate      time.Time  `gorm:"type:date;not null;uniqueIndex:idx_gratitude_user_date" json:"entry_date"`
+	Items          []string   `gorm:"-" json:"items"`                                 // Decrypted on read
+	EncryptedItems []byte     `gorm:"type:bytea;not null" json:"-"`                   // AES-GCM encrypted JSON array
+	ItemCount      int        `gorm:"not null" json:"item_count"`                     // Kept in the clear for recaps
+	FeelCheckID    *uuid.UUID `gorm:"type:uuid;index" json:"feel_check_id,omitempty"` // The same day's check-in, if any
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
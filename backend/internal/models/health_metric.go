@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Health import sources
+const (
+	HealthSourceAppleHealth = "apple_health" // export.xml or the export.zip it comes in
+	HealthSourceCSV         = "csv"          // date, sleep_hours, steps, resting_heart_rate
+)
+
+// Import statuses
+const (
+	ImportPending    = "pending"
+	ImportProcessing = "processing"
+	ImportCompleted  = "completed"
+	ImportFailed     = "failed"
+)
+
+// HealthImport is an uploaded health export waiting for or going through parsing
+type HealthImport struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Source         string     `gorm:"size:20;not null" json:"source"`
+	Status         string     `gorm:"size:20;not null;index" json:"status"`
+	FileName       string     `gorm:"size:255" json:"file_name"`
+	FilePath       string     `gorm:"size:500;not null" json:"-"` // Removed once parsed
+	TotalBytes     int64      `gorm:"not null;default:0" json:"total_bytes"`
+	ProcessedBytes int64      `gorm:"not null;default:0" json:"processed_bytes"`
+	Progress       int        `gorm:"not null;default:0" json:"progress"` // Percent of the file parsed
+	RecordsRead    int        `gorm:"not null;default:0" json:"records_read"`
+	DaysImported   int        `gorm:"not null;default:0" json:"days_imported"`
+	Error          string     `gorm:"size:500" json:"error,omitempty"`
+	StartedAt      *time.Time `json:"started_at"`
+	CompletedAt    *time.Time `json:"completed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// DailyMetric holds one day of imported sleep and activity data. Missing
+// metrics stay NULL so a later import with other metrics can fill them in.
+type DailyMetric struct {
+	ID               uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID           uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_daily_metric_user_date" json:"user_id"`
+	Date             time.Time `gorm:"type:date;not null;uniqueIndex:idx_daily_metric_user_date" json:"date"`
+	SleepHours       *float64  `json:"sleep_hours"`
+	Steps            *int      `json:"steps"`
+	RestingHeartRate *float64  `json:"resting_heart_rate"` // Beats per minute
+	Source           string    `gorm:"size:20;not null" json:"source"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKey stores the response to a mutating request so retries with the
+// same Idempotency-Key header replay it instead of running the handler again.
+// A row without CompletedAt is a request still in progress.
+type IdempotencyKey struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_idempotency_user_key" json:"user_id"`
+	Key         string     `gorm:"size:255;not null;uniqueIndex:idx_idempotency_user_key" json:"key"`
+	RequestHash string     `gorm:"size:64;not null" json:"-"` // SHA-256 of method, path and body
+	StatusCode  int        `json:"status_code"`
+	ContentType string     `gorm:"size:255" json:"-"`
+	Response    []byte     `gorm:"type:bytea" json:"-"` // Encrypted with the user's note data key
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   time.Time  `gorm:"not null;index" json:"expires_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Mindfulness session types
+var MindfulnessTypes = []string{"breathing", "meditation", "body_scan", "mindful_walk"}
+
+// MaxMindfulnessDuration is the longest session that can be logged
+const MaxMindfulnessDuration = 4 * time.Hour
+
+// MindfulnessSession is a breathing or meditation session with mood and energy
+// rated before it starts and after it ends. SessionDate is the day it started,
+// on the same calendar as FeelCheck.CheckDate.
+type MindfulnessSession struct {
+	ID              uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID          uuid.UUID  `gorm:"type:uuid;not null;index:idx_mindfulness_user_date" json:"user_id"`
+	Type            string     `gorm:"size:20;not null" json:"type"`
+	SessionDate     time.Time  `gorm:"type:date;not null;index:idx_mindfulness_user_date" json:"session_date"`
+	StartedAt       time.Time  `gorm:"not null" json:"started_at"`
+	CompletedAt     *time.Time `json:"completed_at"`
+	DurationSeconds int        `gorm:"not null;default:0" json:"duration_seconds"`
+	MoodBefore      int        `gorm:"not null" json:"mood_before"`   // 1-100
+	EnergyBefore    int        `gorm:"not null" json:"energy_before"` // 1-100
+	MoodAfter       *int       `json:"mood_after"`                    // Set on completion
+	EnergyAfter     *int       `json:"energy_after"`                  // Set on completion
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuestionnaireResult is a completed, scored self-assessment. The band is stored
+// so history keeps its meaning if a definition changes.
+type QuestionnaireResult struct {
+	ID              uuid.UUID            `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID          uuid.UUID            `gorm:"type:uuid;not null;index:idx_questionnaire_result_user" json:"user_id"`
+	QuestionnaireID string               `gorm:"size:30;not null;index:idx_questionnaire_result_user" json:"questionnaire_id"`
+	Version         int                  `gorm:"not null" json:"version"`
+	Answers         QuestionnaireAnswers `gorm:"type:jsonb;not null;default:'{}'" json:"answers"`
+	Score           int                  `gorm:"not null" json:"score"`
+	MaxScore        int                  `gorm:"not null" json:"max_score"`
+	Severity        string               `gorm:"size:30;not null" json:"severity"`
+	SeverityLabel   string               `gorm:"size:100;not null" json:"severity_label"`
+	SafetyFlag      bool                 `gorm:"not null;default:false" json:"safety_flag"`
+	CompletedAt     time.Time            `gorm:"not null;index:idx_questionnaire_result_user" json:"completed_at"`
+	CreatedAt       time.Time            `json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// QuestionnaireAnswers maps item IDs to option values, stored as a JSON object
+type QuestionnaireAnswers map[string]int
+
+func (a QuestionnaireAnswers) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (a *QuestionnaireAnswers) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*a = QuestionnaireAnswers{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, a)
+	case string:
+		return json.Unmarshal([]byte(v), a)
+	default:
+		return errors.New("unsupported questionnaire answers value")
+	}
+}
+
+// QuestionnaireSchedule prompts a user to repeat a questionnaire periodically
+type QuestionnaireSchedule struct {
+	ID              uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID          uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_questionnaire_schedule" json:"user_id"`
+	QuestionnaireID string     `gorm:"size:30;not null;uniqueIndex:idx_questionnaire_schedule" json:"questionnaire_id"`
+	Enabled         bool       `gorm:"not null" json:"enabled"`
+	IntervalDays    int        `gorm:"not null" json:"interval_days"`
+	NextDueAt       time.Time  `gorm:"not null;index" json:"next_due_at"`
+	PromptedAt      *time.Time `json:"prompted_at"` // Set once the prompt for NextDueAt went out
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
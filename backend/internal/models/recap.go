@@ -0,0 +1,82 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Recap periods
+const (
+	RecapWeekly  = "week"
+	RecapMonthly = "month"
+)
+
+// Recap is a precomputed weekly or monthly summary of a user's check-ins.
+// Periods are calendar weeks (Monday start) and months in the user's time zone.
+type Recap struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_recap_user_period" json:"user_id"`
+	PeriodType     string     `gorm:"size:10;not null;uniqueIndex:idx_recap_user_period" json:"period_type"`
+	PeriodStart    time.Time  `gorm:"type:date;not null;uniqueIndex:idx_recap_user_period" json:"period_start"`
+	PeriodEnd      time.Time  `gorm:"type:date;not null" json:"period_end"` // Inclusive
+	CheckIns       int        `gorm:"not null;default:0" json:"check_ins"`
+	AvgScore       *float64   `json:"avg_score"`
+	PrevAvgScore   *float64   `json:"prev_avg_score"`
+	ScoreChange    *float64   `json:"score_change"`
+	BestDay        *time.Time `gorm:"type:date" json:"best_day"`
+	BestScore      *int       `json:"best_score"`
+	WorstDay       *time.Time `gorm:"type:date" json:"worst_day"`
+	WorstScore     *int       `json:"worst_score"`
+	CurrentStreak  int        `gorm:"not null;default:0" json:"current_streak"`
+	LongestStreak  int        `gorm:"not null;default:0" json:"longest_streak"` // Longest run within the period
+	TopTags        RecapItems `gorm:"type:jsonb;not null;default:'[]'" json:"top_tags"`
+	TopEmotions    RecapItems `gorm:"type:jsonb;not null;default:'[]'" json:"top_emotions"`
+	VibesReceived  int        `gorm:"not null;default:0" json:"vibes_received"`
+	GratitudeDays  int        `gorm:"not null;default:0" json:"gratitude_days"`  // Days with a gratitude entry
+	GratitudeItems int        `gorm:"not null;default:0" json:"gratitude_items"` // Items across those days
+	BadgesEarned   []string   `gorm:"type:text[];default:'{}'" json:"badges_earned"`
+	ReadAt         *time.Time `json:"read_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// RecapItem is a ranked tag or emotion in a recap
+type RecapItem struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	Icon  string `json:"icon,omitempty"`
+	Count int    `json:"count"`
+}
+
+// RecapItems is stored as a JSON array
+type RecapItems []RecapItem
+
+func (r RecapItems) Value() (driver.Value, error) {
+	if r == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (r *RecapItems) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*r = RecapItems{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	default:
+		return errors.New("unsupported recap items value")
+	}
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Reflection prompt categories
+var PromptCategories = []string{"gratitude", "energy", "growth", "connection", "self_care", "challenge"}
+
+// ReflectionPrompt is a daily question for the check-in note, e.g.
+// "What gave you energy today?". It is preferred on days whose feel score
+// falls within [MinScore, MaxScore].
+type ReflectionPrompt struct {
+	ID        uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Key       *string        `gorm:"size:50;uniqueIndex" json:"key,omitempty"` // Stable key of built-in prompts
+	Text      string         `gorm:"size:280;not null" json:"text"`
+	Category  string         `gorm:"size:20;not null;index" json:"category"`
+	Locale    string         `gorm:"size:10;not null;default:'en';index" json:"locale"`
+	MinScore  int            `gorm:"not null;default:1" json:"min_score"`
+	MaxScore  int            `gorm:"not null;default:100" json:"max_score"`
+	Active    bool           `gorm:"not null;default:true" json:"active"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// DailyPrompt records the prompt a user was given on a day, so it stays the
+// same all day and is not repeated soon after
+type DailyPrompt struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_daily_prompt_user_date" json:"user_id"`
+	PromptDate time.Time `gorm:"type:date;not null;uniqueIndex:idx_daily_prompt_user_date" json:"prompt_date"`
+	PromptID   uuid.UUID `gorm:"type:uuid;not null;index" json:"prompt_id"`
+	ScoreBased bool      `gorm:"not null;default:false" json:"score_based"` // Chosen with the day's check-in score
+	CreatedAt  time.Time `json:"created_at"`
+
+	User   User             `gorm:"foreignKey:UserID" json:"-"`
+	Prompt ReflectionPrompt `gorm:"foreignKey:PromptID" json:"-"`
+}
+
+// DefaultPrompts is the built-in prompt library, inserted by key when missing
+var DefaultPrompts = []ReflectionPrompt{
+	{Key: promptKey("en-energy-gave"), Locale: "en", Category: "energy", Text: "What gave you energy today?"},
+	{Key: promptKey("en-energy-drained"), Locale: "en", Category: "energy", Text: "What drained your energy today, and what could soften it tomorrow?", MaxScore: 60},
+	{Key: promptKey("en-energy-peak"), Locale: "en", Category: "energy", Text: "When did you feel most awake and alive today?", MinScore: 50},
+	{Key: promptKey("en-gratitude-three"), Locale: "en", Category: "gratitude", Text: "Name three things you're grateful for today."},
+	{Key: promptKey("en-gratitude-small"), Locale: "en", Category: "gratitude", Text: "What small moment made today a little better?"},
+	{Key: promptKey("en-gratitude-person"), Locale: "en", Category: "gratitude", Text: "Who are you thankful for right now, and why?", MinScore: 40},
+	{Key: promptKey("en-growth-learned"), Locale: "en", Category: "growth", Text: "What did you learn about yourself today?"},
+	{Key: promptKey("en-growth-proud"), Locale: "en", Category: "growth", Text: "What are you proud of today, however small?", MinScore: 45},
+	{Key: promptKey("en-growth-differently"), Locale: "en", Category: "growth", Text: "If you could replay today, what would you do differently?", MinScore: 30},
+	{Key: promptKey("en-connection-who"), Locale: "en", Category: "connection", Text: "Who did you connect with today, and how did it feel?"},
+	{Key: promptKey("en-connection-reach"), Locale: "en", Category: "connection", Text: "Is there someone you'd like to reach out to tomorrow?", MaxScore: 60},
+	{Key: promptKey("en-connection-kind"), Locale: "en", Category: "connection", Text: "What kind thing did someone do for you, or you for someone?", MinScore: 50},
+	{Key: promptKey("en-selfcare-need"), Locale: "en", Category: "self_care", Text: "What do you need most right now?", MaxScore: 50},
+	{Key: promptKey("en-selfcare-rest"), Locale: "en", Category: "self_care", Text: "How did you take care of yourself today?"},
+	{Key: promptKey("en-selfcare-gentle"), Locale: "en", Category: "self_care", Text: "What would you say to a friend who had the day you had?", MaxScore: 45},
+	{Key: promptKey("en-challenge-hard"), Locale: "en", Category: "challenge", Text: "What was the hardest part of today?", MaxScore: 55},
+	{Key: promptKey("en-challenge-handled"), Locale: "en", Category: "challenge", Text: "What challenge did you handle better than you expected?", MinScore: 40},
+	{Key: promptKey("en-challenge-control"), Locale: "en", Category: "challenge", Text: "What's on your mind that's outside your control? What's within it?", MaxScore: 60},
+
+	{Key: promptKey("tr-energy-gave"), Locale: "tr", Category: "energy", Text: "Bugün sana ne enerji verdi?"},
+	{Key: promptKey("tr-energy-drained"), Locale: "tr", Category: "energy", Text: "Bugün enerjini ne tüketti, yarın bunu ne hafifletebilir?", MaxScore: 60},
+	{Key: promptKey("tr-gratitude-three"), Locale: "tr", Category: "gratitude", Text: "Bugün minnettar olduğun üç şeyi yaz."},
+	{Key: promptKey("tr-gratitude-small"), Locale: "tr", Category: "gratitude", Text: "Bugünü biraz daha güzel yapan küçük an neydi?"},
+	{Key: promptKey("tr-growth-learned"), Locale: "tr", Category: "growth", Text: "Bugün kendin hakkında ne öğrendin?"},
+	{Key: promptKey("tr-connection-who"), Locale: "tr", Category: "connection", Text: "Bugün kiminle bağ kurdun, nasıl hissettirdi?"},
+	{Key: promptKey("tr-selfcare-need"), Locale: "tr", Category: "self_care", Text: "Şu an en çok neye ihtiyacın var?", MaxScore: 50},
+	{Key: promptKey("tr-challenge-hard"), Locale: "tr", Category: "challenge", Text: "Bugünün en zor kısmı neydi?", MaxScore: 55},
+
+	{Key: promptKey("es-energy-gave"), Locale: "es", Category: "energy", Text: "¿Qué te dio energía hoy?"},
+	{Key: promptKey("es-energy-drained"), Locale: "es", Category: "energy", Text: "¿Qué te quitó energía hoy y qué podría aliviarlo mañana?", MaxScore: 60},
+	{Key: promptKey("es-gratitude-three"), Locale: "es", Category: "gratitude", Text: "Nombra tres cosas por las que estás agradecido hoy."},
+	{Key: promptKey("es-gratitude-small"), Locale: "es", Category: "gratitude", Text: "¿Qué pequeño momento hizo que hoy fuera un poco mejor?"},
+	{Key: promptKey("es-growth-learned"), Locale: "es", Category: "growth", Text: "¿Qué aprendiste sobre ti hoy?"},
+	{Key: promptKey("es-connection-who"), Locale: "es", Category: "connection", Text: "¿Con quién conectaste hoy y cómo te sentiste?"},
+	{Key: promptKey("es-selfcare-need"), Locale: "es", Category: "self_care", Text: "¿Qué es lo que más necesitas ahora mismo?", MaxScore: 50},
+	{Key: promptKey("es-challenge-hard"), Locale: "es", Category: "challenge", Text: "¿Cuál fue la parte más difícil de hoy?", MaxScore: 55},
+}
+
+func promptKey(key string) *string {
+	return &key
+}
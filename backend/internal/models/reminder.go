@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReminderSetting holds a user's daily check-in reminder schedule
+type ReminderSetting struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	Enabled   bool      `gorm:"not null;index" json:"enabled"`
+	Times     []string  `gorm:"type:text[];default:'{}'" json:"times"` // Local "HH:MM"
+	Weekdays  int       `gorm:"not null;default:127" json:"weekdays"`  // Bitmask, bit 0 = Sunday
+	Timezone  string    `gorm:"size:64;not null;default:'UTC'" json:"timezone"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// OnWeekday reports whether reminders are scheduled on the given weekday
+func (r *ReminderSetting) OnWeekday(day time.Weekday) bool {
+	return r.Weekdays&(1<<uint(day)) != 0
+}
+
+// ReminderDelivery claims one reminder slot. The unique index lets exactly one
+// scheduler instance send each reminder.
+type ReminderDelivery struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_reminder_delivery_slot" json:"user_id"`
+	ScheduledFor time.Time `gorm:"not null;uniqueIndex:idx_reminder_delivery_slot" json:"scheduled_for"`
+	CreatedAt    time.Time `gorm:"index" json:"created_at"`
+}
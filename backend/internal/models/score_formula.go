@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"math"
+)
+
+// ScoreBand is a color/label range on the feel score scale
+type ScoreBand struct {
+	Min   int    `json:"min"`
+	Max   int    `json:"max"`
+	Color string `json:"color"`
+	Label string `json:"label"`
+}
+
+// ScoreFormula combines mood and energy into a feel score and maps scores to bands.
+// Formulas are versioned so every check-in records which one produced its score.
+type ScoreFormula struct {
+	Version      int         `json:"version"`
+	Name         string      `json:"name"`
+	MoodWeight   float64     `json:"mood_weight"`
+	EnergyWeight float64     `json:"energy_weight"`
+	Curve        string      `json:"curve"` // linear, ease-in, ease-out
+	Bands        []ScoreBand `json:"bands"` // Ordered from highest to lowest
+}
+
+// defaultScoreBands matches the original GetColorHex thresholds
+var defaultScoreBands = []ScoreBand{
+	{Min: 90, Max: 100, Color: "#22c55e", Label: "Amazing"},
+	{Min: 75, Max: 89, Color: "#84cc16", Label: "Great"},
+	{Min: 60, Max: 74, Color: "#eab308", Label: "Good"},
+	{Min: 45, Max: 59, Color: "#f97316", Label: "Okay"},
+	{Min: 30, Max: 44, Color: "#ef4444", Label: "Not great"},
+	{Min: 1, Max: 29, Color: "#8b5cf6", Label: "Low"},
+}
+
+// ScoreFormulas is the registry of known formula versions
+var ScoreFormulas = map[int]ScoreFormula{
+	1: {Version: 1, Name: "Balanced average", MoodWeight: 0.5, EnergyWeight: 0.5, Curve: "linear", Bands: defaultScoreBands},
+	2: {Version: 2, Name: "Mood weighted", MoodWeight: 0.6, EnergyWeight: 0.4, Curve: "linear", Bands: defaultScoreBands},
+	3: {Version: 3, Name: "Mood weighted, lifted lows", MoodWeight: 0.6, EnergyWeight: 0.4, Curve: "ease-out", Bands: defaultScoreBands},
+}
+
+var activeScoreVersion = 1
+
+// SetActiveScoreVersion selects the formula used for new check-ins
+func SetActiveScoreVersion(version int) error {
+	if _, ok := ScoreFormulas[version]; !ok {
+		return fmt.Errorf("unknown feel score version %d", version)
+	}
+	activeScoreVersion = version
+	return nil
+}
+
+// ActiveScoreFormula returns the formula used for new check-ins
+func ActiveScoreFormula() ScoreFormula {
+	return ScoreFormulas[activeScoreVersion]
+}
+
+// ScoreFormulaFor returns the formula for a version, falling back to v1 for legacy rows
+func ScoreFormulaFor(version int) ScoreFormula {
+	if formula, ok := ScoreFormulas[version]; ok {
+		return formula
+	}
+	return ScoreFormulas[1]
+}
+
+// Score computes the combined feel score (1-100)
+func (sf ScoreFormula) Score(mood, energy int) int {
+	x := (sf.MoodWeight*float64(mood) + sf.EnergyWeight*float64(energy)) / (sf.MoodWeight + sf.EnergyWeight) / 100
+
+	switch sf.Curve {
+	case "ease-in":
+		x = x * x
+	case "ease-out":
+		x = math.Sqrt(x)
+	}
+
+	// Floor keeps v1 identical to the original integer (mood+energy)/2
+	score := int(math.Floor(x*100 + 1e-9))
+	if score < 1 {
+		return 1
+	}
+	if score > 100 {
+		return 100
+	}
+	return score
+}
+
+// Band returns the band a score falls into
+func (sf ScoreFormula) Band(score int) ScoreBand {
+	for _, band := range sf.Bands {
+		if score >= band.Min {
+			return band
+		}
+	}
+	return sf.Bands[len(sf.Bands)-1]
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShareGrant gives someone without an account read-only access to part of a
+// user's history through a secret link; only the token's hash is stored.
+type ShareGrant struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Label          string     `gorm:"size:100" json:"label"` // Who it was shared with, e.g. "Dr. Lee"
+	TokenHash      string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	FromDate       time.Time  `gorm:"type:date;not null" json:"from_date"`
+	ToDate         *time.Time `gorm:"type:date" json:"to_date"` // Nil keeps the range open up to the current day
+	IncludeNotes   bool       `gorm:"not null;default:false" json:"include_notes"`
+	ExpiresAt      time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	LastAccessedAt *time.Time `json:"last_accessed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// Active reports whether the grant can still be used at t
+func (g *ShareGrant) Active(t time.Time) bool {
+	return g.RevokedAt == nil && t.Before(g.ExpiresAt)
+}
+
+// ShareAccess logs one request made with a share grant
+type ShareAccess struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	GrantID    uuid.UUID `gorm:"type:uuid;not null;index" json:"grant_id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index" json:"-"`
+	Resource   string    `gorm:"size:20;not null" json:"resource"` // grant, history or insights
+	IP         string    `gorm:"size:45" json:"ip"`
+	UserAgent  string    `gorm:"size:255" json:"user_agent"`
+	AccessedAt time.Time `gorm:"not null;index" json:"accessed_at"`
+
+	Grant ShareGrant `gorm:"foreignKey:GrantID" json:"-"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Tag is an activity or context label attached to check-ins (sleep, exercise, work...).
+// Default tags have no owner and are shared by all users.
+type Tag struct {
+	ID        uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID    *uuid.UUID     `gorm:"type:uuid;index" json:"user_id,omitempty"` // nil for default tags
+	Name      string         `gorm:"not null;size:50" json:"name"`
+	Category  string         `gorm:"not null;size:20;default:'activity'" json:"category"` // activity, context, health
+	Icon      string         `gorm:"size:10" json:"icon"`
+	IsDefault bool           `gorm:"not null;default:false" json:"is_default"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// DefaultTags is the built-in tag set available to every user
+var DefaultTags = []Tag{
+	{Name: "Good sleep", Category: "health", Icon: "😴"},
+	{Name: "Poor sleep", Category: "health", Icon: "🥱"},
+	{Name: "Exercise", Category: "activity", Icon: "🏃"},
+	{Name: "Outdoors", Category: "activity", Icon: "🌳"},
+	{Name: "Work", Category: "context", Icon: "💼"},
+	{Name: "Study", Category: "context", Icon: "📚"},
+	{Name: "Social", Category: "context", Icon: "👯"},
+	{Name: "Family", Category: "context", Icon: "👨‍👩‍👧"},
+	{Name: "Caffeine", Category: "health", Icon: "☕"},
+	{Name: "Alcohol", Category: "health", Icon: "🍷"},
+	{Name: "Screen time", Category: "activity", Icon: "📱"},
+	{Name: "Meditation", Category: "activity", Icon: "🧘"},
+}
@@ -7,11 +7,31 @@ import (
 	"gorm.io/gorm"
 )
 
+// User roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
 	ID        uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
 	Email     string         `gorm:"uniqueIndex;not null;size:255" json:"email"`
 	Password  string         `gorm:"not null" json:"-"`
+	Timezone  string         `gorm:"size:64;not null;default:'UTC'" json:"timezone"` // IANA name, e.g. Europe/Istanbul
+	Role      string         `gorm:"size:20;not null;default:'user'" json:"-"`       // user or admin; granted with cmd/admin
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
+
+// Location returns the user's time zone, falling back to UTC
+func (u *User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserKey holds a user's note data key, wrapped (AES-GCM) by a server master key.
+// Rows are hard-deleted on account deletion, which crypto-shreds every note of the user.
+type UserKey struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	WrappedKey  []byte    `gorm:"type:bytea;not null" json:"-"`
+	MasterKeyID string    `gorm:"not null;size:50;index" json:"master_key_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	User        User      `gorm:"foreignKey:UserID" json:"-"`
+}
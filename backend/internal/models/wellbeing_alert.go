@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Wellbeing alert kinds
+const (
+	AlertSustainedLow   = "sustained_low"   // Several low days in a row
+	AlertSharpDrop      = "sharp_drop"      // Score well below the recent average
+	AlertHighVolatility = "high_volatility" // Scores swinging widely
+)
+
+// WellbeingAlert flags a concerning mood pattern so the app can offer support
+type WellbeingAlert struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index:idx_wellbeing_user_kind" json:"user_id"`
+	Kind        string     `gorm:"size:30;not null;index:idx_wellbeing_user_kind" json:"kind"`
+	FeelCheckID *uuid.UUID `gorm:"type:uuid" json:"feel_check_id"`
+	FeelScore   int        `json:"feel_score"` // Score of the check-in that triggered the alert
+	Value       float64    `json:"value"`      // Low days, drop in points or standard deviation
+	DismissedAt *time.Time `json:"dismissed_at"`
+	CreatedAt   time.Time  `gorm:"index:idx_wellbeing_user_kind" json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WrappedVersion is the current layout of the year-in-review document.
+// Bump it when the document changes; older summaries are regenerated by the job.
+const WrappedVersion = 1
+
+// WrappedSummary stores a user's "Feelsy Wrapped" year in review
+type WrappedSummary struct {
+	ID          uuid.UUID       `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID      uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex:idx_wrapped_user_year" json:"user_id"`
+	Year        int             `gorm:"not null;uniqueIndex:idx_wrapped_user_year" json:"year"`
+	Version     int             `gorm:"not null" json:"version"`
+	Document    WrappedDocument `gorm:"type:jsonb;not null" json:"document"`
+	GeneratedAt time.Time       `gorm:"not null" json:"generated_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// WrappedDocument is the JSON document the mobile app renders
+type WrappedDocument struct {
+	Version          int             `json:"version"`
+	Year             int             `json:"year"`
+	Timezone         string          `json:"timezone"`
+	TotalCheckIns    int             `json:"total_check_ins"`
+	LongestStreak    WrappedStreak   `json:"longest_streak"`
+	Months           []WrappedMonth  `json:"months"`
+	TopEmoji         *WrappedEmoji   `json:"top_emoji"`
+	SupportiveFriend *WrappedFriend  `json:"supportive_friend"`
+	HappiestWeekday  *WrappedWeekday `json:"happiest_weekday"`
+	BadgesEarned     []string        `json:"badges_earned"`
+	GeneratedAt      time.Time       `json:"generated_at"`
+}
+
+// WrappedStreak is the longest run of consecutive check-in days in the year
+type WrappedStreak struct {
+	Days      int    `json:"days"`
+	StartDate string `json:"start_date,omitempty"` // YYYY-MM-DD
+	EndDate   string `json:"end_date,omitempty"`
+}
+
+// WrappedMonth is the mood of a single month
+type WrappedMonth struct {
+	Month     int      `json:"month"` // 1-12
+	CheckIns  int      `json:"check_ins"`
+	AvgScore  *float64 `json:"avg_score"`
+	MoodEmoji string   `json:"mood_emoji,omitempty"` // Most used emoji that month
+	ColorHex  string   `json:"color_hex,omitempty"`
+	Label     string   `json:"label,omitempty"`
+}
+
+// WrappedEmoji is the most frequently used emoji
+type WrappedEmoji struct {
+	Emoji string `json:"emoji"`
+	Count int    `json:"count"`
+}
+
+// WrappedFriend is the friend the user exchanged the most vibes with
+type WrappedFriend struct {
+	UserID        uuid.UUID `json:"user_id"`
+	Email         string    `json:"email"`
+	VibesSent     int       `json:"vibes_sent"`
+	VibesReceived int       `json:"vibes_received"`
+}
+
+// WrappedWeekday is the weekday with the highest average feel score
+type WrappedWeekday struct {
+	Weekday  string  `json:"weekday"`
+	AvgScore float64 `json:"avg_score"`
+	CheckIns int     `json:"check_ins"`
+}
+
+func (d WrappedDocument) Value() (driver.Value, error) {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (d *WrappedDocument) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, d)
+	case string:
+		return json.Unmarshal([]byte(v), d)
+	default:
+		return errors.New("unsupported wrapped document value")
+	}
+}
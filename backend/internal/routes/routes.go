@@ -4,17 +4,39 @@ import (
 	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/config"
 	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/handlers"
 	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/middleware"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/services"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/etag"
 )
 
 func Setup(
 	app *fiber.App,
 	cfg *config.Config,
+	authService *services.AuthService,
 	authHandler *handlers.AuthHandler,
 	healthHandler *handlers.HealthHandler,
 	webhookHandler *handlers.WebhookHandler,
 	moderationHandler *handlers.ModerationHandler,
 	feelHandler *handlers.FeelHandler,
+	insightsHandler *handlers.InsightsHandler,
+	tagHandler *handlers.TagHandler,
+	searchHandler *handlers.SearchHandler,
+	recapHandler *handlers.RecapHandler,
+	wrappedHandler *handlers.WrappedHandler,
+	wellbeingHandler *handlers.WellbeingHandler,
+	goalHandler *handlers.GoalHandler,
+	reminderHandler *handlers.ReminderHandler,
+	forecastHandler *handlers.ForecastHandler,
+	healthImportHandler *handlers.HealthImportHandler,
+	moodImportHandler *handlers.MoodImportHandler,
+	exportHandler *handlers.ExportHandler,
+	shareHandler *handlers.ShareHandler,
+	questionnaireHandler *handlers.QuestionnaireHandler,
+	promptHandler *handlers.PromptHandler,
+	photoHandler *handlers.PhotoHandler,
+	gratitudeHandler *handlers.GratitudeHandler,
+	mindfulnessHandler *handlers.MindfulnessHandler,
+	idempotencyService *services.IdempotencyService,
 ) {
 	api := app.Group("/api")
 
@@ -28,10 +50,29 @@ func Setup(
 	auth.Post("/refresh", authHandler.Refresh)
 	auth.Post("/apple", authHandler.AppleSignIn) // Sign in with Apple (Guideline 4.8)
 
+	// Health data upload (protected). Registered ahead of the protected group so
+	// the Idempotency middleware never buffers the streamed upload.
+	api.Post("/imports/health", middleware.JWTProtected(cfg), healthImportHandler.CreateImport)
+	api.Post("/feels/:id/photos", middleware.JWTProtected(cfg), photoHandler.AddPhoto) // Attach a JPEG or PNG (field "photo")
+
+	// Check-in photos on local storage (public, authorized by the signed URL)
+	api.Get("/media/*", photoHandler.GetMedia)
+
+	// Calendar feed (public, authorized by the secret token in the URL)
+	api.Get("/calendar/:token.ics", exportHandler.GetCalendarFeed)
+
+	// Share links (public, read-only, authorized by the secret token in the URL)
+	shared := api.Group("/shared/:token")
+	shared.Get("", shareHandler.GetSharedView)              // Scope & expiry of the link
+	shared.Get("/history", shareHandler.GetSharedHistory)   // History within the shared range
+	shared.Get("/insights", shareHandler.GetSharedInsights) // Trends within the shared range
+
 	// Auth (protected)
-	protected := api.Group("", middleware.JWTProtected(cfg))
+	// Mutating requests may carry an Idempotency-Key for safe retries
+	protected := api.Group("", middleware.JWTProtected(cfg), middleware.Idempotency(idempotencyService))
 	protected.Post("/auth/logout", authHandler.Logout)
 	protected.Delete("/auth/account", authHandler.DeleteAccount) // Account deletion (Guideline 5.1.1)
+	protected.Put("/auth/timezone", authHandler.UpdateTimezone)  // Time zone for recaps
 
 	// Moderation - User endpoints (protected)
 	protected.Post("/reports", moderationHandler.CreateReport)     // Report content (Guideline 1.2)
@@ -40,19 +81,101 @@ func Setup(
 
 	// Feelsy - Daily mood check-ins (protected)
 	feels := protected.Group("/feels")
-	feels.Post("", feelHandler.CreateFeelCheck)         // Create daily check-in
-	feels.Get("/today", feelHandler.GetTodayCheck)      // Get today's check-in
-	feels.Get("/history", feelHandler.GetFeelHistory)   // Get check-in history
-	feels.Get("/stats", feelHandler.GetFeelStats)       // Get stats & streaks
-	feels.Post("/vibe", feelHandler.SendGoodVibe)       // Send good vibes to friend
-	feels.Get("/vibes", feelHandler.GetReceivedVibes)   // Get received vibes
-	feels.Get("/friends", feelHandler.GetFriendFeels)   // Get friend feels today
-
-	// Admin moderation panel (protected + admin check)
-	// In production, add an admin role middleware here
-	admin := api.Group("/admin", middleware.JWTProtected(cfg))
+	feels.Post("", feelHandler.CreateFeelCheck)                         // Create daily check-in
+	feels.Get("/today", feelHandler.GetTodayCheck)                      // Get today's check-in
+	feels.Get("/history", feelHandler.GetFeelHistory)                   // Get check-in history
+	feels.Get("/stats", feelHandler.GetFeelStats)                       // Get stats & streaks
+	feels.Get("/insights", insightsHandler.GetInsights)                 // Mood trends & analytics
+	feels.Get("/insights/tags", insightsHandler.GetTagInsights)         // Tag effects on scores
+	feels.Get("/insights/emotions", insightsHandler.GetEmotionInsights) // Grouped by emotion & quadrant
+	feels.Get("/insights/health", insightsHandler.GetHealthInsights)    // Sleep & activity vs scores
+	feels.Get("/prompt", promptHandler.GetDailyPrompt)                  // Today's reflection prompt
+	feels.Get("/forecast", forecastHandler.GetForecast)                 // Tomorrow's forecast with confidence bands
+	feels.Get("/calendar", etag.New(), feelHandler.GetCalendar)         // Calendar heatmap (ETag cached)
+	feels.Get("/scale", feelHandler.GetScale)                           // Score formula, colors & labels
+	feels.Get("/emotions", feelHandler.GetEmotions)                     // Emotion catalog
+	feels.Get("/search", searchHandler.SearchFeels)                     // Full-text search over notes
+	feels.Get("/export", exportHandler.ExportFeels)                     // Download history as CSV or JSON Lines
+	feels.Post("/export/calendar", exportHandler.CreateCalendarFeed)    // New .ics feed URL (revokes the old one)
+	feels.Delete("/export/calendar", exportHandler.RevokeCalendarFeed)  // Revoke the .ics feed URL
+	feels.Post("/vibe", feelHandler.SendGoodVibe)                       // Send good vibes to friend
+	feels.Get("/vibes", feelHandler.GetReceivedVibes)                   // Get received vibes
+	feels.Get("/friends", feelHandler.GetFriendFeels)                   // Get friend feels today
+	feels.Put("/:id/tags", feelHandler.SetFeelCheckTags)                // Replace tags on a check-in
+	feels.Get("/:id/photos", photoHandler.ListPhotos)                   // Photos with signed URLs
+	feels.Delete("/:id/photos/:photoId", photoHandler.DeletePhoto)      // Remove a photo and its files
+	feels.Post("/sync", feelHandler.SyncFeelChecks)                     // Batch upload offline check-ins
+	feels.Get("/sync", feelHandler.GetFeelDelta)                        // Changes since a sync token
+	feels.Get("/recaps", recapHandler.GetRecaps)                        // Weekly & monthly recaps (marks read)
+	feels.Get("/wrapped", wrappedHandler.GetWrapped)                    // Year in review
+	feels.Get("/wellbeing", wellbeingHandler.GetWellbeing)              // Active low-mood alerts & resources
+	feels.Post("/wellbeing/:id/dismiss", wellbeingHandler.DismissAlert) // Dismiss an alert
+
+	// Activity & context tags (protected)
+	tags := protected.Group("/tags")
+	tags.Get("", tagHandler.ListTags)         // Default + user-defined tags
+	tags.Post("", tagHandler.CreateTag)       // Create user tag
+	tags.Put("/:id", tagHandler.UpdateTag)    // Update user tag
+	tags.Delete("/:id", tagHandler.DeleteTag) // Delete user tag
+
+	// Personal goals (protected)
+	goals := protected.Group("/goals")
+	goals.Get("", goalHandler.ListGoals)                   // Goals with current-period progress
+	goals.Post("", goalHandler.CreateGoal)                 // Create goal
+	goals.Get("/completions", goalHandler.ListCompletions) // Completed goal history (marks seen)
+	goals.Put("/:id", goalHandler.UpdateGoal)              // Update title, target or active state
+	goals.Delete("/:id", goalHandler.DeleteGoal)           // Delete goal
+
+	// Gratitude journal, up to three items a day (protected)
+	gratitude := protected.Group("/gratitude")
+	gratitude.Get("", gratitudeHandler.GetHistory)           // Entries, newest first
+	gratitude.Put("", gratitudeHandler.SaveEntry)            // Replace a day's items (defaults to today)
+	gratitude.Get("/streak", gratitudeHandler.GetStreak)     // Gratitude streak, separate from check-ins
+	gratitude.Get("/export", exportHandler.ExportGratitude)  // Download as CSV or JSON Lines
+	gratitude.Delete("/:date", gratitudeHandler.DeleteEntry) // Remove a day's entry
+
+	// Breathing & meditation sessions (protected)
+	mindfulness := protected.Group("/mindfulness")
+	mindfulness.Get("/sessions", mindfulnessHandler.ListSessions)                  // Sessions with before/after deltas
+	mindfulness.Post("/sessions", mindfulnessHandler.StartSession)                 // Start with mood & energy before
+	mindfulness.Post("/sessions/:id/complete", mindfulnessHandler.CompleteSession) // Finish with mood & energy after
+	mindfulness.Get("/insights", insightsHandler.GetMindfulnessInsights)           // Mood lift per type & session days vs check-ins
+
+	// Check-in reminders (protected)
+	protected.Get("/reminders", reminderHandler.GetSettings)    // Reminder times, weekdays & time zone
+	protected.Put("/reminders", reminderHandler.UpdateSettings) // Replace reminder settings
+
+	// Wellbeing questionnaires: PHQ-9, GAD-7, WHO-5 (protected)
+	questionnaires := protected.Group("/questionnaires")
+	questionnaires.Get("", questionnaireHandler.ListQuestionnaires)          // Catalog with schedule & latest result
+	questionnaires.Get("/:id", questionnaireHandler.GetQuestionnaire)        // Items, options & severity bands
+	questionnaires.Post("/:id/results", questionnaireHandler.SubmitResult)   // Score & store answers
+	questionnaires.Get("/:id/results", questionnaireHandler.ListResults)     // Result history
+	questionnaires.Get("/:id/trend", questionnaireHandler.GetTrend)          // Scores next to check-in averages
+	questionnaires.Put("/:id/schedule", questionnaireHandler.UpdateSchedule) // Periodic prompts
+
+	// Share links for therapists & caregivers (protected)
+	shares := protected.Group("/shares")
+	shares.Get("", shareHandler.ListGrants)                // Share links with status & access counts
+	shares.Post("", shareHandler.CreateGrant)              // New link (URL shown once)
+	shares.Delete("/:id", shareHandler.RevokeGrant)        // Revoke immediately
+	shares.Get("/:id/accesses", shareHandler.ListAccesses) // Access log
+
+	// Data imports (protected)
+	protected.Get("/imports/health", healthImportHandler.ListImports)   // Recent health imports
+	protected.Get("/imports/health/:id", healthImportHandler.GetImport) // Health import status & progress
+	protected.Post("/imports/mood", moodImportHandler.ImportMoods)      // History from Daylio, Bearable or CSV (dry_run=true to preview)
+
+	// Admin panel (protected + admin role, granted with cmd/admin)
+	admin := api.Group("/admin", middleware.JWTProtected(cfg), middleware.RequireAdmin(authService))
 	admin.Get("/moderation/reports", moderationHandler.ListReports)
 	admin.Put("/moderation/reports/:id", moderationHandler.ActionReport)
+	admin.Post("/feels/rescore", feelHandler.RescoreHistory)              // Recompute scores with a formula version (active one set by FEEL_SCORE_VERSION)
+	admin.Post("/wrapped/:userId/preview", wrappedHandler.PreviewWrapped) // Regenerate a user's year in review (not stored)
+	admin.Get("/prompts", promptHandler.ListPrompts)                      // Reflection prompt library
+	admin.Post("/prompts", promptHandler.CreatePrompt)                    // Add a prompt
+	admin.Put("/prompts/:id", promptHandler.UpdatePrompt)                 // Edit text, category, locale, score range or active state
+	admin.Delete("/prompts/:id", promptHandler.DeletePrompt)              // Remove from rotation
 
 	// Webhooks (verified by auth header, not JWT)
 	webhooks := api.Group("/webhooks")
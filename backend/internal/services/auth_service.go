@@ -24,15 +24,17 @@ var (
 	ErrInvalidCredentials = errors.New("invalid email or password")
 	ErrInvalidToken       = errors.New("invalid or expired refresh token")
 	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidTimezone    = errors.New("invalid timezone")
 )
 
 type AuthService struct {
-	db  *gorm.DB
-	cfg *config.Config
+	db     *gorm.DB
+	cfg    *config.Config
+	photos *PhotoService
 }
 
-func NewAuthService(db *gorm.DB, cfg *config.Config) *AuthService {
-	return &AuthService{db: db, cfg: cfg}
+func NewAuthService(db *gorm.DB, cfg *config.Config, photos *PhotoService) *AuthService {
+	return &AuthService{db: db, cfg: cfg, photos: photos}
 }
 
 func (s *AuthService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, error) {
@@ -108,7 +110,7 @@ func (s *AuthService) Logout(req *dto.LogoutRequest) error {
 }
 
 // DeleteAccount implements Apple Guideline 5.1.1(v) - account deletion.
-// Scrubs all user data: tokens, subscriptions, reports, blocks, then soft-deletes user.
+// Scrubs all user data: tokens, subscriptions, reports, blocks, note keys, then soft-deletes user.
 func (s *AuthService) DeleteAccount(userID uuid.UUID, password string) error {
 	var user models.User
 	if err := s.db.First(&user, "id = ?", userID).Error; err != nil {
@@ -122,6 +124,11 @@ func (s *AuthService) DeleteAccount(userID uuid.UUID, password string) error {
 		}
 	}
 
+	// Photo files live outside the database, so remove them before the rows
+	if err := s.photos.DeleteUserPhotos(userID); err != nil {
+		return err
+	}
+
 	// Scrub all associated data in a transaction
 	return s.db.Transaction(func(tx *gorm.DB) error {
 		// Revoke all refresh tokens
@@ -136,11 +143,160 @@ func (s *AuthService) DeleteAccount(userID uuid.UUID, password string) error {
 		// Remove blocks
 		tx.Where("blocker_id = ? OR blocked_id = ?", userID, userID).Delete(&models.Block{})
 
+		// Crypto-shred check-in notes by destroying the user's note data key
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserKey{}).Error; err != nil {
+			return err
+		}
+
+		// Emotions tagged on check-ins describe how the user felt, so they go too
+		if err := tx.Where("feel_check_id IN (SELECT id FROM feel_checks WHERE user_id = ?)", userID).Delete(&models.FeelCheckEmotion{}).Error; err != nil {
+			return err
+		}
+
+		// Recaps, badges, year-in-review summaries, wellbeing alerts and forecasts are derived from check-in history
+		if err := tx.Where("user_id = ?", userID).Delete(&models.Recap{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.BadgeUnlock{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.WrappedSummary{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.WellbeingAlert{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MoodForecast{}).Error; err != nil {
+			return err
+		}
+
+		// Remove questionnaire results and schedules
+		if err := tx.Where("user_id = ?", userID).Delete(&models.QuestionnaireResult{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.QuestionnaireSchedule{}).Error; err != nil {
+			return err
+		}
+
+		// Remove mindfulness sessions
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MindfulnessSession{}).Error; err != nil {
+			return err
+		}
+
+		// Remove the gratitude journal
+		if err := tx.Where("user_id = ?", userID).Delete(&models.GratitudeEntry{}).Error; err != nil {
+			return err
+		}
+
+		// Remove the daily reflection prompt history
+		if err := tx.Where("user_id = ?", userID).Delete(&models.DailyPrompt{}).Error; err != nil {
+			return err
+		}
+
+		// Remove reminder settings and the delivery log
+		if err := tx.Where("user_id = ?", userID).Delete(&models.ReminderSetting{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.ReminderDelivery{}).Error; err != nil {
+			return err
+		}
+
+		// Remove goals and their history
+		if err := tx.Where("user_id = ?", userID).Delete(&models.GoalCompletion{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.Goal{}).Error; err != nil {
+			return err
+		}
+
+		// Remove the user's own tags, including ones already soft-deleted
+		if err := tx.Exec("DELETE FROM feel_check_tags WHERE tag_id IN (SELECT id FROM tags WHERE user_id = ?)", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.Tag{}).Error; err != nil {
+			return err
+		}
+
+		// Remove imported health data
+		if err := tx.Where("user_id = ?", userID).Delete(&models.DailyMetric{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.HealthImport{}).Error; err != nil {
+			return err
+		}
+
+		// Revoke the calendar feed URL
+		if err := tx.Where("user_id = ?", userID).Delete(&models.CalendarFeed{}).Error; err != nil {
+			return err
+		}
+
+		// Revoke share links and drop their access log
+		if err := tx.Where("user_id = ?", userID).Delete(&models.ShareAccess{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.ShareGrant{}).Error; err != nil {
+			return err
+		}
+
+		// Stored responses for retried requests may contain check-in data
+		if err := tx.Where("user_id = ?", userID).Delete(&models.IdempotencyKey{}).Error; err != nil {
+			return err
+		}
+
 		// Soft-delete the user (GORM DeletedAt)
 		return tx.Delete(&user).Error
 	})
 }
 
+// UpdateTimezone sets the time zone used for the user's recaps
+func (s *AuthService) UpdateTimezone(userID uuid.UUID, timezone string) error {
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" || timezone == "Local" {
+		return ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return ErrInvalidTimezone
+	}
+
+	result := s.db.Model(&models.User{}).Where("id = ?", userID).Update("timezone", timezone)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// IsAdmin reports whether the user has the admin role. The role is read on
+// every call so revoking it takes effect immediately.
+func (s *AuthService) IsAdmin(userID uuid.UUID) (bool, error) {
+	var user models.User
+	err := s.db.Select("role").First(&user, "id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return user.Role == models.RoleAdmin, nil
+}
+
+// SetRole grants or revokes the admin role by email
+func (s *AuthService) SetRole(email, role string) error {
+	if role != models.RoleUser && role != models.RoleAdmin {
+		return fmt.Errorf("role must be %s or %s", models.RoleUser, models.RoleAdmin)
+	}
+	result := s.db.Model(&models.User{}).Where("email = ?", email).Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
 // AppleSignIn handles Sign in with Apple (Guideline 4.8).
 // Verifies Apple identity token and creates/finds a user.
 func (s *AuthService) AppleSignIn(req *dto.AppleSignInRequest) (*dto.AuthResponse, error) {
@@ -0,0 +1,394 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	exportBatchSize = 500
+	// Cells starting with these are run as formulas by spreadsheet apps
+	csvFormulaPrefixes = "=+-@\t\r"
+)
+
+// Export formats
+const (
+	ExportCSV   = "csv"
+	ExportJSONL = "jsonl"
+)
+
+var (
+	ErrInvalidExportFormat  = errors.New("format must be csv or jsonl")
+	ErrInvalidExportRange   = errors.New("from and to must be dates (YYYY-MM-DD) with from before to")
+	ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+)
+
+type ExportService struct {
+	db      *gorm.DB
+	notes   *NoteCipher
+	baseURL string
+}
+
+// NewExportService builds feed URLs on baseURL, the server's public address.
+// When empty, the address the request came in on is used.
+func NewExportService(db *gorm.DB, notes *NoteCipher, baseURL string) *ExportService {
+	return &ExportService{db: db, notes: notes, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// ExportFeels writes the user's check-ins, oldest first, as CSV or JSON Lines
+func (s *ExportService) ExportFeels(userID uuid.UUID, req *dto.FeelExportQuery, w io.Writer) error {
+	format := req.Format
+	if format == "" {
+		format = ExportCSV
+	}
+	if format != ExportCSV && format != ExportJSONL {
+		return ErrInvalidExportFormat
+	}
+
+	query, err := exportRange(s.db.Where("user_id = ?", userID), "check_date", req.From, req.To)
+	if err != nil {
+		return err
+	}
+
+	var write func(row *dto.FeelExportRow) error
+	var flush func() error
+	if format == ExportCSV {
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"date", "mood_score", "energy_score", "feel_score", "mood_emoji",
+			"emotions", "tags", "note", "created_at", "edited_at", "id"})
+		write = func(row *dto.FeelExportRow) error {
+			edited := ""
+			if row.EditedAt != nil {
+				edited = row.EditedAt.UTC().Format(time.RFC3339)
+			}
+			return cw.Write([]string{
+				row.Date,
+				strconv.Itoa(row.MoodScore),
+				strconv.Itoa(row.EnergyScore),
+				strconv.Itoa(row.FeelScore),
+				csvSafe(row.MoodEmoji),
+				strings.Join(row.Emotions, ";"),
+				csvSafe(strings.Join(row.Tags, ";")),
+				csvSafe(row.Note),
+				row.CreatedAt.UTC().Format(time.RFC3339),
+				edited,
+				row.ID,
+			})
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	} else {
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		write = func(row *dto.FeelExportRow) error { return enc.Encode(row) }
+		flush = func() error { return nil }
+	}
+
+	for offset := 0; ; offset += exportBatchSize {
+		var checks []models.FeelCheck
+		err := query.Session(&gorm.Session{}).
+			Preload("Tags").
+			Preload("Emotions").
+			Order("check_date, created_at, id").
+			Offset(offset).
+			Limit(exportBatchSize).
+			Find(&checks).Error
+		if err != nil {
+			return err
+		}
+		if err := s.notes.DecryptNotes(userID, checks); err != nil {
+			return err
+		}
+		for i := range checks {
+			if err := write(exportRow(&checks[i])); err != nil {
+				return err
+			}
+		}
+		if len(checks) < exportBatchSize {
+			break
+		}
+	}
+	return flush()
+}
+
+// ExportGratitude writes the user's gratitude entries, oldest first, as CSV or JSON Lines
+func (s *ExportService) ExportGratitude(userID uuid.UUID, req *dto.GratitudeExportQuery, w io.Writer) error {
+	format := req.Format
+	if format == "" {
+		format = ExportCSV
+	}
+	if format != ExportCSV && format != ExportJSONL {
+		return ErrInvalidExportFormat
+	}
+
+	query, err := exportRange(s.db.Where("user_id = ?", userID), "entry_date", req.From, req.To)
+	if err != nil {
+		return err
+	}
+
+	var write func(row *dto.GratitudeExportRow) error
+	var flush func() error
+	if format == ExportCSV {
+		cw := csv.NewWriter(w)
+		header := []string{"date"}
+		for i := 1; i <= models.MaxGratitudeItems; i++ {
+			header = append(header, fmt.Sprintf("item_%d", i))
+		}
+		cw.Write(append(header, "feel_check_id", "created_at", "updated_at", "id"))
+		write = func(row *dto.GratitudeExportRow) error {
+			record := []string{row.Date}
+			for i := 0; i < models.MaxGratitudeItems; i++ {
+				item := ""
+				if i < len(row.Items) {
+					item = csvSafe(row.Items[i])
+				}
+				record = append(record, item)
+			}
+			return cw.Write(append(record,
+				row.FeelCheckID,
+				row.CreatedAt.UTC().Format(time.RFC3339),
+				row.UpdatedAt.UTC().Format(time.RFC3339),
+				row.ID,
+			))
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	} else {
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		write = func(row *dto.GratitudeExportRow) error { return enc.Encode(row) }
+		flush = func() error { return nil }
+	}
+
+	for offset := 0; ; offset += exportBatchSize {
+		var entries []models.GratitudeEntry
+		err := query.Session(&gorm.Session{}).
+			Order("entry_date, id").
+			Offset(offset).
+			Limit(exportBatchSize).
+			Find(&entries).Error
+		if err != nil {
+			return err
+		}
+		if err := s.notes.DecryptGratitude(userID, entries); err != nil {
+			return err
+		}
+		for _, e := range entries {
+			row := &dto.GratitudeExportRow{
+				ID:        e.ID.String(),
+				Date:      e.EntryDate.Format("2006-01-02"),
+				Items:     e.Items,
+				CreatedAt: e.CreatedAt,
+				UpdatedAt: e.UpdatedAt,
+			}
+			if e.FeelCheckID != nil {
+				row.FeelCheckID = e.FeelCheckID.String()
+			}
+			if err := write(row); err != nil {
+				return err
+			}
+		}
+		if len(entries) < exportBatchSize {
+			break
+		}
+	}
+	return flush()
+}
+
+// CreateCalendarFeed issues a new feed URL, revoking any previous one
+func (s *ExportService) CreateCalendarFeed(userID uuid.UUID, requestBaseURL string) (*dto.CalendarFeedResponse, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	feed := models.CalendarFeed{UserID: userID, TokenHash: hashToken(token), CreatedAt: time.Now()}
+	err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"token_hash": feed.TokenHash, "created_at": feed.CreatedAt, "last_accessed_at": nil}),
+	}).Create(&feed).Error
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := s.baseURL
+	if baseURL == "" {
+		baseURL = strings.TrimRight(requestBaseURL, "/")
+	}
+	return &dto.CalendarFeedResponse{
+		URL:       baseURL + "/api/calendar/" + token + ".ics",
+		CreatedAt: feed.CreatedAt,
+	}, nil
+}
+
+// RevokeCalendarFeed disables the user's feed URL
+func (s *ExportService) RevokeCalendarFeed(userID uuid.UUID) error {
+	result := s.db.Where("user_id = ?", userID).Delete(&models.CalendarFeed{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCalendarFeedNotFound
+	}
+	return nil
+}
+
+// WriteCalendarFeed writes the feed for token as iCalendar, one all-day event per
+// check-in. Notes stay out of the feed since anyone with the URL can read it.
+func (s *ExportService) WriteCalendarFeed(token string, w io.Writer) error {
+	var feed models.CalendarFeed
+	err := s.db.Where("token_hash = ?", hashToken(token)).First(&feed).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrCalendarFeedNotFound
+	}
+	if err != nil {
+		return err
+	}
+	s.db.Model(&feed).UpdateColumn("last_accessed_at", time.Now())
+
+	ics := &icsWriter{w: w}
+	ics.line("BEGIN:VCALENDAR")
+	ics.line("VERSION:2.0")
+	ics.line("PRODID:-//Feelsy//Check-ins//EN")
+	ics.line("CALSCALE:GREGORIAN")
+	ics.line("METHOD:PUBLISH")
+	ics.line("X-WR-CALNAME:Feelsy check-ins")
+	ics.line("REFRESH-INTERVAL;VALUE=DURATION:PT6H")
+
+	for offset := 0; ; offset += exportBatchSize {
+		var checks []models.FeelCheck
+		err := s.db.Select("id", "feel_score", "mood_score", "energy_score", "mood_emoji", "score_version", "check_date", "updated_at").
+			Where("user_id = ?", feed.UserID).
+			Order("check_date, id").
+			Offset(offset).
+			Limit(exportBatchSize).
+			Find(&checks).Error
+		if err != nil {
+			return err
+		}
+		for _, c := range checks {
+			title := fmt.Sprintf("%d · %s", c.FeelScore, models.ScoreFormulaFor(c.ScoreVersion).Band(c.FeelScore).Label)
+			if c.MoodEmoji != "" {
+				title = c.MoodEmoji + " " + title
+			}
+			ics.line("BEGIN:VEVENT")
+			ics.line("UID:" + c.ID.String() + "@feelsy")
+			ics.line("DTSTAMP:" + c.UpdatedAt.UTC().Format("20060102T150405Z"))
+			ics.line("DTSTART;VALUE=DATE:" + c.CheckDate.Format("20060102"))
+			ics.line("DTEND;VALUE=DATE:" + c.CheckDate.AddDate(0, 0, 1).Format("20060102"))
+			ics.line("SUMMARY:" + icsEscape(title))
+			ics.line("DESCRIPTION:" + icsEscape(fmt.Sprintf("Mood %d · Energy %d", c.MoodScore, c.EnergyScore)))
+			ics.line("TRANSP:TRANSPARENT")
+			ics.line("END:VEVENT")
+		}
+		if len(checks) < exportBatchSize {
+			break
+		}
+	}
+
+	ics.line("END:VCALENDAR")
+	return ics.err
+}
+
+func exportRow(check *models.FeelCheck) *dto.FeelExportRow {
+	row := &dto.FeelExportRow{
+		ID:          check.ID.String(),
+		Date:        check.CheckDate.Format("2006-01-02"),
+		MoodScore:   check.MoodScore,
+		EnergyScore: check.EnergyScore,
+		FeelScore:   check.FeelScore,
+		MoodEmoji:   check.MoodEmoji,
+		Emotions:    make([]string, 0, len(check.Emotions)),
+		Tags:        make([]string, 0, len(check.Tags)),
+		Note:        check.Note,
+		CreatedAt:   check.CreatedAt,
+		EditedAt:    check.EditedAt,
+	}
+	for _, e := range check.Emotions {
+		row.Emotions = append(row.Emotions, e.EmotionID)
+	}
+	for _, t := range check.Tags {
+		row.Tags = append(row.Tags, t.Name)
+	}
+	return row
+}
+
+// exportRange limits query to the inclusive YYYY-MM-DD range on column
+func exportRange(query *gorm.DB, column, rawFrom, rawTo string) (*gorm.DB, error) {
+	var from, to time.Time
+	var err error
+	if rawFrom != "" {
+		if from, err = time.Parse("2006-01-02", rawFrom); err != nil {
+			return nil, ErrInvalidExportRange
+		}
+		query = query.Where(column+" >= ?", from)
+	}
+	if rawTo != "" {
+		if to, err = time.Parse("2006-01-02", rawTo); err != nil {
+			return nil, ErrInvalidExportRange
+		}
+		query = query.Where(column+" <= ?", to)
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, ErrInvalidExportRange
+	}
+	return query, nil
+}
+
+// csvSafe stops spreadsheets from running cells that start like a formula
+func csvSafe(v string) string {
+	if v != "" && strings.ContainsRune(csvFormulaPrefixes, rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+// icsEscape escapes TEXT values (RFC 5545 3.3.11)
+func icsEscape(v string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(v)
+}
+
+// icsWriter writes CRLF-terminated content lines folded at 75 octets
+type icsWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (i *icsWriter) line(content string) {
+	if i.err != nil {
+		return
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range content {
+		n := utf8.RuneLen(r)
+		if width+n > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += n
+	}
+	b.WriteString("\r\n")
+	_, i.err = io.WriteString(i.w, b.String())
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// FeelFilter narrows check-in queries by date, score and emoji
+type FeelFilter struct {
+	From     *time.Time
+	To       *time.Time
+	MinScore int
+	MaxScore int
+	Emoji    string
+}
+
+// parseFeelFilter validates raw query values into a FeelFilter
+func parseFeelFilter(from, to string, minScore, maxScore int, emoji string) (FeelFilter, error) {
+	var f FeelFilter
+
+	if from != "" {
+		t, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return f, errors.New("from must be a date (YYYY-MM-DD)")
+		}
+		f.From = &t
+	}
+	if to != "" {
+		t, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return f, errors.New("to must be a date (YYYY-MM-DD)")
+		}
+		f.To = &t
+	}
+	if f.From != nil && f.To != nil && f.From.After(*f.To) {
+		return f, errors.New("from must not be after to")
+	}
+
+	if minScore < 0 || minScore > 100 || maxScore < 0 || maxScore > 100 {
+		return f, errors.New("score filters must be between 1 and 100")
+	}
+	if minScore > 0 && maxScore > 0 && minScore > maxScore {
+		return f, errors.New("min_score must not exceed max_score")
+	}
+	f.MinScore, f.MaxScore = minScore, maxScore
+	f.Emoji = strings.TrimSpace(emoji)
+
+	return f, nil
+}
+
+// apply adds the filter conditions to a feel_checks query
+func (f FeelFilter) apply(query *gorm.DB) *gorm.DB {
+	if f.From != nil {
+		query = query.Where("check_date >= ?", *f.From)
+	}
+	if f.To != nil {
+		query = query.Where("check_date <= ?", *f.To)
+	}
+	if f.MinScore > 0 {
+		query = query.Where("feel_score >= ?", f.MinScore)
+	}
+	if f.MaxScore > 0 {
+		query = query.Where("feel_score <= ?", f.MaxScore)
+	}
+	if f.Emoji != "" {
+		query = query.Where("mood_emoji = ?", f.Emoji)
+	}
+	return query
+}
+
+// encodeCursor builds an opaque keyset cursor from a (check_date, id) position
+func encodeCursor(checkDate time.Time, id uuid.UUID) string {
+	raw := checkDate.Format("2006-01-02") + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor reverses encodeCursor
+func decodeCursor(cursor string) (time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	datePart, idPart, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	checkDate, err := time.Parse("2006-01-02", datePart)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+	id, err := uuid.Parse(idPart)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	return checkDate, id, nil
+}
@@ -2,25 +2,46 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"log"
+	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
 	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxNoteLength       = 280 // Characters
+	maxEmotionsPerCheck = 5
+)
+
+var (
+	ErrInvalidCalendarPeriod = errors.New("invalid calendar period")
+	ErrUnknownScoreVersion   = errors.New("unknown feel score version")
 )
 
 type FeelService struct {
-	db *gorm.DB
+	db        *gorm.DB
+	notes     *NoteCipher
+	wellbeing *WellbeingService
+	goals     *GoalService
+	photos    *PhotoService
 }
 
-func NewFeelService(db *gorm.DB) *FeelService {
-	return &FeelService{db: db}
+func NewFeelService(db *gorm.DB, notes *NoteCipher, wellbeing *WellbeingService, goals *GoalService, photos *PhotoService) *FeelService {
+	return &FeelService{db: db, notes: notes, wellbeing: wellbeing, goals: goals, photos: photos}
 }
 
-// CreateFeelCheck creates a new daily mood check-in
-func (s *FeelService) CreateFeelCheck(userID uuid.UUID, moodScore, energyScore int, moodEmoji, note string) (*models.FeelCheck, error) {
+// CreateFeelCheck creates a new daily mood check-in. Any wellbeing alert it raises
+// is returned with resources for the locale.
+func (s *FeelService) CreateFeelCheck(userID uuid.UUID, req *dto.CreateFeelCheckRequest, locale string) (*dto.CreateFeelCheckResponse, error) {
 	// Validate scores
-	if moodScore < 1 || moodScore > 100 || energyScore < 1 || energyScore > 100 {
+	if req.MoodScore < 1 || req.MoodScore > 100 || req.EnergyScore < 1 || req.EnergyScore > 100 {
 		return nil, errors.New("scores must be between 1 and 100")
 	}
 
@@ -33,52 +54,314 @@ func (s *FeelService) CreateFeelCheck(userID uuid.UUID, moodScore, energyScore i
 		return nil, errors.New("already checked in today")
 	}
 
+	if utf8.RuneCountInString(req.Note) > maxNoteLength {
+		return nil, errors.New("note must be at most 280 characters")
+	}
+
+	tags, err := findUsableTags(s.db, userID, req.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	emotions, err := parseEmotions(req.EmotionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	promptID, err := findPrompt(s.db, req.PromptID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without an explicit emoji, use the primary emotion's default
+	moodEmoji := strings.TrimSpace(req.MoodEmoji)
+	if moodEmoji == "" && len(emotions) > 0 {
+		emotion, _ := models.FindEmotion(emotions[0].EmotionID)
+		moodEmoji = emotion.DefaultEmoji
+	}
+	if len(moodEmoji) > 10 {
+		return nil, errors.New("mood_emoji must be a single emoji")
+	}
+
+	encryptedNote, err := s.notes.Encrypt(userID, req.Note)
+	if err != nil {
+		return nil, err
+	}
+
 	check := &models.FeelCheck{
-		UserID:      userID,
-		MoodScore:   moodScore,
-		EnergyScore: energyScore,
-		MoodEmoji:   moodEmoji,
-		Note:        note,
-		CheckDate:   today,
+		UserID:        userID,
+		MoodScore:     req.MoodScore,
+		EnergyScore:   req.EnergyScore,
+		MoodEmoji:     moodEmoji,
+		Note:          req.Note,
+		EncryptedNote: encryptedNote,
+		CheckDate:     today,
+		Tags:          tags,
+		Emotions:      emotions,
+		PromptID:      promptID,
 	}
 	check.CalculateFeelScore()
 	check.ColorHex = check.GetColorHex()
 
-	if err := s.db.Create(check).Error; err != nil {
+	if err := s.db.Omit("Tags.*").Create(check).Error; err != nil {
 		return nil, err
 	}
 
-	// Update streak
-	go s.UpdateStreak(userID)
+	// Link a gratitude list written earlier in the day
+	if err := linkGratitudeEntry(s.db, userID, check.CheckDate, check.ID); err != nil {
+		log.Printf("Gratitude link failed for user %s: %v", userID, err)
+	}
 
-	return check, nil
+	// Update streak, then goal progress (goal badges are added to the saved streak)
+	go func() {
+		s.UpdateStreak(userID)
+		if err := s.goals.EvaluateGoals(userID); err != nil {
+			log.Printf("Goal evaluation failed for user %s: %v", userID, err)
+		}
+	}()
+
+	resp := &dto.CreateFeelCheckResponse{FeelCheck: check}
+
+	// Flag sustained low mood, sharp drops and volatility; never fails the check-in
+	alert, err := s.wellbeing.Evaluate(userID)
+	if err != nil {
+		log.Printf("Wellbeing evaluation failed for user %s: %v", userID, err)
+	}
+	if alert != nil {
+		resp.WellbeingAlert = alert
+		resp.Resources = s.wellbeing.Resources(locale)
+	}
+
+	return resp, nil
+}
+
+// SetFeelCheckTags replaces the tags attached to one of the user's check-ins
+func (s *FeelService) SetFeelCheckTags(userID, checkID uuid.UUID, tagIDs []string) (*models.FeelCheck, error) {
+	var check models.FeelCheck
+	if err := s.db.Where("id = ? AND user_id = ?", checkID, userID).First(&check).Error; err != nil {
+		return nil, err
+	}
+
+	tags, err := findUsableTags(s.db, userID, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Model(&check).Omit("Tags.*").Association("Tags").Replace(tags); err != nil {
+		return nil, err
+	}
+	check.Tags = tags
+
+	// Touch the row so delta sync picks up the tag change
+	now := time.Now()
+	if err := s.db.Model(&check).UpdateColumn("edited_at", now).Error; err != nil {
+		return nil, err
+	}
+	check.EditedAt = &now
+
+	checks := []models.FeelCheck{check}
+	if err := s.notes.DecryptNotes(userID, checks); err != nil {
+		return nil, err
+	}
+
+	return &checks[0], nil
 }
 
 // GetTodayCheck returns today's check-in for a user
 func (s *FeelService) GetTodayCheck(userID uuid.UUID) (*models.FeelCheck, error) {
 	today := time.Now().Truncate(24 * time.Hour)
 	var check models.FeelCheck
-	err := s.db.Where("user_id = ? AND check_date = ?", userID, today).First(&check).Error
+	err := s.db.Preload("Tags").Preload("Emotions").Where("user_id = ? AND check_date = ?", userID, today).First(&check).Error
 	if err != nil {
 		return nil, err
 	}
-	return &check, nil
+
+	checks := []models.FeelCheck{check}
+	if err := s.notes.DecryptNotes(userID, checks); err != nil {
+		return nil, err
+	}
+	return &checks[0], nil
+}
+
+// historyColumns maps selectable history fields to the columns they need
+var historyColumns = map[string][]string{
+	"mood_score":    {"mood_score"},
+	"energy_score":  {"energy_score"},
+	"feel_score":    {"feel_score"},
+	"mood_emoji":    {"mood_emoji"},
+	"note":          {"encrypted_note", "note"},
+	"color_hex":     {"color_hex"},
+	"score_version": {"score_version"},
+	"created_at":    {"created_at"},
+	"tags":          {},
+	"emotions":      {},
+}
+
+// FeelHistoryOptions is a validated history query
+type FeelHistoryOptions struct {
+	Filter     FeelFilter
+	Limit      int
+	Ascending  bool
+	Fields     map[string]bool
+	cursorDate time.Time
+	cursorID   uuid.UUID
+	hasCursor  bool
 }
 
-// GetFeelHistory returns check-in history for a user
-func (s *FeelService) GetFeelHistory(userID uuid.UUID, limit, offset int) ([]models.FeelCheck, int64, error) {
+// ParseHistoryQuery validates history query parameters
+func (s *FeelService) ParseHistoryQuery(req *dto.FeelHistoryQuery) (*FeelHistoryOptions, error) {
+	opts := &FeelHistoryOptions{Limit: req.Limit, Fields: map[string]bool{}}
+
+	if opts.Limit == 0 {
+		opts.Limit = 20
+	}
+	if opts.Limit < 1 || opts.Limit > 100 {
+		return nil, errors.New("limit must be between 1 and 100")
+	}
+
+	switch req.Sort {
+	case "", "desc":
+	case "asc":
+		opts.Ascending = true
+	default:
+		return nil, errors.New("sort must be asc or desc")
+	}
+
+	filter, err := parseFeelFilter(req.From, req.To, req.MinScore, req.MaxScore, req.Emoji)
+	if err != nil {
+		return nil, err
+	}
+	opts.Filter = filter
+
+	if req.Fields == "" {
+		for field := range historyColumns {
+			opts.Fields[field] = true
+		}
+	} else {
+		for _, field := range strings.Split(req.Fields, ",") {
+			field = strings.TrimSpace(field)
+			if field == "id" || field == "check_date" || field == "" {
+				continue
+			}
+			if _, ok := historyColumns[field]; !ok {
+				return nil, fmt.Errorf("unknown field: %s", field)
+			}
+			opts.Fields[field] = true
+		}
+	}
+
+	if req.Cursor != "" {
+		opts.cursorDate, opts.cursorID, err = decodeCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		opts.hasCursor = true
+	}
+
+	return opts, nil
+}
+
+// GetFeelHistory returns a keyset-paginated page of check-in history for a user
+func (s *FeelService) GetFeelHistory(userID uuid.UUID, opts *FeelHistoryOptions) (*dto.FeelHistoryResponse, error) {
+	columns := []string{"id", "user_id", "check_date"}
+	for field := range opts.Fields {
+		columns = append(columns, historyColumns[field]...)
+	}
+
+	query := s.db.Select(columns).Where("user_id = ?", userID)
+	query = opts.Filter.apply(query)
+	if opts.Fields["tags"] {
+		query = query.Preload("Tags")
+	}
+	if opts.Fields["emotions"] {
+		query = query.Preload("Emotions")
+	}
+
+	order := "check_date DESC, id DESC"
+	if opts.Ascending {
+		order = "check_date ASC, id ASC"
+	}
+	if opts.hasCursor {
+		if opts.Ascending {
+			query = query.Where("(check_date, id) > (?, ?)", opts.cursorDate, opts.cursorID)
+		} else {
+			query = query.Where("(check_date, id) < (?, ?)", opts.cursorDate, opts.cursorID)
+		}
+	}
+
 	var checks []models.FeelCheck
-	var total int64
+	if err := query.Order(order).Limit(opts.Limit + 1).Find(&checks).Error; err != nil {
+		return nil, err
+	}
 
-	s.db.Model(&models.FeelCheck{}).Where("user_id = ?", userID).Count(&total)
+	resp := &dto.FeelHistoryResponse{
+		Data:  make([]dto.FeelHistoryItem, 0, len(checks)),
+		Limit: opts.Limit,
+	}
+	if len(checks) > opts.Limit {
+		checks = checks[:opts.Limit]
+		last := checks[len(checks)-1]
+		resp.HasMore = true
+		resp.NextCursor = encodeCursor(last.CheckDate, last.ID)
+	}
 
-	err := s.db.Where("user_id = ?", userID).
-		Order("check_date DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&checks).Error
+	if opts.Fields["note"] {
+		if err := s.notes.DecryptNotes(userID, checks); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range checks {
+		resp.Data = append(resp.Data, historyItem(&checks[i], opts.Fields))
+	}
 
-	return checks, total, err
+	return resp, nil
+}
+
+// historyItem projects a check-in onto the requested fields
+func historyItem(check *models.FeelCheck, fields map[string]bool) dto.FeelHistoryItem {
+	item := dto.FeelHistoryItem{
+		ID:        check.ID.String(),
+		CheckDate: check.CheckDate.Format("2006-01-02"),
+	}
+	if fields["mood_score"] {
+		item.MoodScore = &check.MoodScore
+	}
+	if fields["energy_score"] {
+		item.EnergyScore = &check.EnergyScore
+	}
+	if fields["feel_score"] {
+		item.FeelScore = &check.FeelScore
+	}
+	if fields["mood_emoji"] {
+		item.MoodEmoji = &check.MoodEmoji
+	}
+	if fields["note"] {
+		item.Note = &check.Note
+	}
+	if fields["color_hex"] {
+		item.ColorHex = &check.ColorHex
+	}
+	if fields["score_version"] {
+		item.ScoreVersion = &check.ScoreVersion
+	}
+	if fields["created_at"] {
+		createdAt := check.CreatedAt.UTC().Format(time.RFC3339)
+		item.CreatedAt = &createdAt
+	}
+	if fields["tags"] {
+		item.Tags = make([]dto.HistoryTag, 0, len(check.Tags))
+		for _, tag := range check.Tags {
+			item.Tags = append(item.Tags, dto.HistoryTag{ID: tag.ID.String(), Name: tag.Name, Icon: tag.Icon})
+		}
+	}
+	if fields["emotions"] {
+		item.Emotions = make([]string, 0, len(check.Emotions))
+		for _, e := range check.Emotions {
+			item.Emotions = append(item.Emotions, e.EmotionID)
+		}
+	}
+	return item
 }
 
 // GetFeelStats returns statistics for a user
@@ -107,6 +390,153 @@ func (s *FeelService) GetFeelStats(userID uuid.UUID) (map[string]interface{}, er
 	}, nil
 }
 
+// GetCalendar returns one cell per day for a month, or for the whole year when month is 0
+func (s *FeelService) GetCalendar(userID uuid.UUID, year, month int) (*dto.FeelCalendarResponse, error) {
+	today := time.Now().Truncate(24 * time.Hour)
+	if year < 2000 || year > today.Year()+1 || month < 0 || month > 12 {
+		return nil, ErrInvalidCalendarPeriod
+	}
+
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, -1)
+	if month > 0 {
+		from = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		to = from.AddDate(0, 1, -1)
+	}
+
+	var checks []models.FeelCheck
+	err := s.db.Select("check_date", "feel_score", "score_version", "mood_emoji").
+		Where("user_id = ? AND check_date BETWEEN ? AND ?", userID, from, to).
+		Find(&checks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	checksByDate := make(map[string]models.FeelCheck, len(checks))
+	for _, check := range checks {
+		checksByDate[check.CheckDate.Format("2006-01-02")] = check
+	}
+
+	resp := &dto.FeelCalendarResponse{
+		Year:  year,
+		Month: month,
+		From:  from.Format("2006-01-02"),
+		To:    to.Format("2006-01-02"),
+		Days:  make([]dto.FeelCalendarCell, 0, int(to.Sub(from).Hours()/24)+1),
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		cell := dto.FeelCalendarCell{Date: key}
+
+		if check, ok := checksByDate[key]; ok {
+			cell.Status = "checked_in"
+			cell.Score = check.FeelScore
+			cell.Color = check.GetColorHex()
+			cell.Emoji = check.MoodEmoji
+		} else if day.After(today) {
+			cell.Status = "future"
+		} else {
+			cell.Status = "missing"
+		}
+
+		resp.Days = append(resp.Days, cell)
+	}
+
+	return resp, nil
+}
+
+// ListEmotions returns the emotion catalog with labels for the given locale
+func (s *FeelService) ListEmotions(locale string) []dto.EmotionResponse {
+	emotions := make([]dto.EmotionResponse, 0, len(models.EmotionCatalog))
+	for _, e := range models.EmotionCatalog {
+		emotions = append(emotions, dto.EmotionResponse{
+			ID:           e.ID,
+			Label:        e.Label(locale),
+			Quadrant:     e.Quadrant,
+			Valence:      e.Valence,
+			Arousal:      e.Arousal,
+			DefaultEmoji: e.DefaultEmoji,
+		})
+	}
+	return emotions
+}
+
+// parseEmotions validates emotion IDs against the catalog, keeping their order
+func parseEmotions(ids []string) ([]models.FeelCheckEmotion, error) {
+	if len(ids) > maxEmotionsPerCheck {
+		return nil, fmt.Errorf("at most %d emotions can be selected", maxEmotionsPerCheck)
+	}
+
+	emotions := make([]models.FeelCheckEmotion, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if _, ok := models.FindEmotion(id); !ok {
+			return nil, fmt.Errorf("unknown emotion: %s", id)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		emotions = append(emotions, models.FeelCheckEmotion{EmotionID: id})
+	}
+	return emotions, nil
+}
+
+// GetScale returns the active feel score formula and its color bands
+func (s *FeelService) GetScale() models.ScoreFormula {
+	return models.ActiveScoreFormula()
+}
+
+// RescoreHistory recomputes every check-in's feel score and color with the given
+// formula version, then refreshes streak averages. Returns the number of check-ins updated.
+// It does not change the active version: new check-ins keep using FEEL_SCORE_VERSION,
+// so switch formulas by setting it and restarting before rescoring with the new version.
+func (s *FeelService) RescoreHistory(version int) (int64, error) {
+	formula, ok := models.ScoreFormulas[version]
+	if !ok {
+		return 0, ErrUnknownScoreVersion
+	}
+
+	var updated int64
+	var checks []models.FeelCheck
+	result := s.db.Select("id", "mood_score", "energy_score").
+		Where("score_version <> ?", version).
+		FindInBatches(&checks, 500, func(tx *gorm.DB, batch int) error {
+			for _, check := range checks {
+				score := formula.Score(check.MoodScore, check.EnergyScore)
+				err := s.db.Model(&models.FeelCheck{}).
+					Where("id = ?", check.ID).
+					Updates(map[string]interface{}{
+						"feel_score":    score,
+						"color_hex":     formula.Band(score).Color,
+						"score_version": formula.Version,
+					}).Error
+				if err != nil {
+					return err
+				}
+				updated++
+			}
+			return nil
+		})
+	if result.Error != nil {
+		return updated, result.Error
+	}
+
+	// Streak averages are derived from feel_score
+	err := s.db.Exec(`
+		UPDATE feel_streaks fs SET average_score = sub.avg_score, updated_at = NOW()
+		FROM (
+			SELECT user_id, AVG(feel_score) AS avg_score
+			FROM feel_checks
+			WHERE deleted_at IS NULL
+			GROUP BY user_id
+		) sub
+		WHERE fs.user_id = sub.user_id`).Error
+
+	return updated, err
+}
+
 // UpdateStreak updates the user's streak after a check-in
 func (s *FeelService) UpdateStreak(userID uuid.UUID) error {
 	today := time.Now().Truncate(24 * time.Hour)
@@ -156,10 +586,34 @@ func (s *FeelService) UpdateStreak(userID uuid.UUID) error {
 		Scan(&avgScore)
 	streak.AverageScore = avgScore
 
+	return s.saveStreak(userID, &streak)
+}
+
+// saveStreak unlocks any newly earned badges and saves the streak
+func (s *FeelService) saveStreak(userID uuid.UUID, streak *models.FeelStreak) error {
 	// Check for badge unlocks
+	previous := make(map[string]bool, len(streak.UnlockedBadges))
+	for _, b := range streak.UnlockedBadges {
+		previous[b] = true
+	}
 	streak.UnlockedBadges = s.checkBadgeUnlocks(streak.CurrentStreak, streak.TotalCheckIns, streak.UnlockedBadges)
 
-	return s.db.Save(&streak).Error
+	if err := s.db.Save(streak).Error; err != nil {
+		return err
+	}
+
+	// Log newly unlocked badges so recaps can report when they were earned
+	now := time.Now()
+	for _, b := range streak.UnlockedBadges {
+		if previous[b] {
+			continue
+		}
+		unlock := models.BadgeUnlock{UserID: userID, Badge: b, UnlockedAt: now}
+		if err := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&unlock).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (s *FeelService) checkBadgeUnlocks(streak, total int, current []string) []string {
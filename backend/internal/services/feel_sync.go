@@ -0,0 +1,448 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxSyncItems   = 100
+	maxSyncAgeDays = 30 // Oldest check-in day accepted from offline clients
+	maxDeltaItems  = 500
+)
+
+// Sync item statuses
+const (
+	SyncCreated       = "created"
+	SyncUpdated       = "updated"
+	SyncDeleted       = "deleted"
+	SyncUnchanged     = "unchanged"
+	SyncStale         = "stale"
+	SyncDuplicateDate = "duplicate_date"
+	SyncRejected      = "rejected"
+)
+
+var (
+	ErrInvalidSyncToken = errors.New("invalid sync token")
+	ErrSyncBatchSize    = fmt.Errorf("items must contain between 1 and %d check-ins", maxSyncItems)
+)
+
+// SyncFeelChecks applies a batch of check-ins recorded offline and reports a
+// result per item. Conflicts are resolved with these rules:
+//
+//   - IDs are idempotent: replaying an item with the same client_updated_at is unchanged
+//   - An existing check-in is only overwritten by a newer client_updated_at (last writer
+//     wins); an older edit is stale and the server copy is returned
+//   - Deletes win: a deleted check-in is never brought back, later edits report deleted
+//   - A new ID on a day that already has a check-in is duplicate_date; the existing
+//     check-in is kept and returned so the client can replace its local copy
+//   - IDs owned by another user and invalid items are rejected
+func (s *FeelService) SyncFeelChecks(userID uuid.UUID, req *dto.SyncFeelChecksRequest) (*dto.SyncFeelChecksResponse, error) {
+	if len(req.Items) == 0 || len(req.Items) > maxSyncItems {
+		return nil, ErrSyncBatchSize
+	}
+
+	resp := &dto.SyncFeelChecksResponse{Results: make([]dto.SyncItemResult, 0, len(req.Items))}
+	changed, created := false, false
+	for i := range req.Items {
+		result, err := s.syncItem(userID, &req.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		switch result.Status {
+		case SyncCreated:
+			changed, created = true, true
+		case SyncUpdated, SyncDeleted:
+			changed = true
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	if changed {
+		// Synced check-ins can arrive out of order, so rebuild instead of incrementing
+		if err := s.RebuildStreak(userID); err != nil {
+			return nil, err
+		}
+		if err := s.goals.EvaluateGoals(userID); err != nil {
+			log.Printf("Goal evaluation failed for user %s: %v", userID, err)
+		}
+	}
+	if created {
+		if _, err := s.wellbeing.Evaluate(userID); err != nil {
+			log.Printf("Wellbeing evaluation failed for user %s: %v", userID, err)
+		}
+	}
+
+	return resp, nil
+}
+
+// GetFeelDelta returns check-ins created, edited or deleted after the sync token.
+// An empty token returns everything from the start.
+func (s *FeelService) GetFeelDelta(userID uuid.UUID, token string) (*dto.FeelDeltaResponse, error) {
+	since, err := decodeSyncToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var checks []models.FeelCheck
+	err = s.db.Unscoped().
+		Preload("Tags").
+		Preload("Emotions").
+		Where("user_id = ? AND sync_version > ?", userID, since).
+		Order("sync_version").
+		Limit(maxDeltaItems + 1).
+		Find(&checks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.FeelDeltaResponse{
+		Changed:   []models.FeelCheck{},
+		Deleted:   []string{},
+		SyncToken: encodeSyncToken(since),
+	}
+	if len(checks) > maxDeltaItems {
+		checks = checks[:maxDeltaItems]
+		resp.HasMore = true
+	}
+	if len(checks) == 0 {
+		return resp, nil
+	}
+	resp.SyncToken = encodeSyncToken(checks[len(checks)-1].SyncVersion)
+
+	live := make([]models.FeelCheck, 0, len(checks))
+	for _, check := range checks {
+		if check.DeletedAt.Valid {
+			resp.Deleted = append(resp.Deleted, check.ID.String())
+		} else {
+			live = append(live, check)
+		}
+	}
+	if err := s.notes.DecryptNotes(userID, live); err != nil {
+		return nil, err
+	}
+	resp.Changed = live
+
+	return resp, nil
+}
+
+// RebuildStreak recomputes the user's streak from their whole history
+func (s *FeelService) RebuildStreak(userID uuid.UUID) error {
+	var islands []struct {
+		EndDate time.Time
+		Days    int
+	}
+	err := s.db.Raw(`
+		WITH days AS (
+			SELECT DISTINCT check_date
+			FROM feel_checks
+			WHERE user_id = ? AND deleted_at IS NULL
+		)
+		SELECT MAX(check_date) AS end_date, COUNT(*) AS days
+		FROM (
+			SELECT check_date, check_date - (ROW_NUMBER() OVER (ORDER BY check_date))::int AS grp
+			FROM days
+		) islands
+		GROUP BY grp
+		ORDER BY end_date`, userID).
+		Scan(&islands).Error
+	if err != nil {
+		return err
+	}
+
+	var stats struct {
+		Total    int
+		AvgScore float64
+	}
+	err = s.db.Model(&models.FeelCheck{}).
+		Select("COUNT(*) AS total, COALESCE(AVG(feel_score), 0) AS avg_score").
+		Where("user_id = ?", userID).
+		Scan(&stats).Error
+	if err != nil {
+		return err
+	}
+
+	var streak models.FeelStreak
+	err = s.db.Where("user_id = ?", userID).First(&streak).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		streak = models.FeelStreak{UserID: userID, UnlockedBadges: []string{}}
+	} else if err != nil {
+		return err
+	}
+
+	streak.TotalCheckIns = stats.Total
+	streak.AverageScore = stats.AvgScore
+	streak.CurrentStreak = 0
+	streak.LastCheckDate = nil
+	for _, island := range islands {
+		if island.Days > streak.LongestStreak {
+			streak.LongestStreak = island.Days
+		}
+	}
+	if len(islands) > 0 {
+		last := islands[len(islands)-1]
+		streak.CurrentStreak = last.Days
+		streak.LastCheckDate = &last.EndDate
+	}
+
+	return s.saveStreak(userID, &streak)
+}
+
+// syncItem applies a single offline item
+func (s *FeelService) syncItem(userID uuid.UUID, item *dto.SyncFeelCheckItem) (dto.SyncItemResult, error) {
+	result := dto.SyncItemResult{ID: item.ID}
+
+	id, err := uuid.Parse(item.ID)
+	if err != nil || id == uuid.Nil {
+		result.Status, result.Message = SyncRejected, "id must be a UUID"
+		return result, nil
+	}
+	result.ID = id.String()
+
+	// Postgres keeps microseconds; truncate so replays compare equal
+	now := time.Now()
+	if item.ClientUpdatedAt.IsZero() {
+		item.ClientUpdatedAt = item.ClientCreatedAt
+	}
+	if item.ClientUpdatedAt.IsZero() || item.ClientUpdatedAt.After(now) {
+		item.ClientUpdatedAt = now
+	}
+	item.ClientUpdatedAt = item.ClientUpdatedAt.Truncate(time.Microsecond)
+
+	var existing models.FeelCheck
+	err = s.db.Unscoped().Preload("Tags").Preload("Emotions").Where("id = ?", id).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if item.Deleted {
+			// Created and deleted offline; nothing to do
+			result.Status = SyncUnchanged
+			return result, nil
+		}
+		return s.syncCreate(userID, id, item)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if existing.UserID != userID {
+		result.Status, result.Message = SyncRejected, "id is already in use"
+		return result, nil
+	}
+	if existing.DeletedAt.Valid {
+		result.Status = SyncDeleted
+		return result, nil
+	}
+
+	editedAt := existing.CreatedAt
+	if existing.EditedAt != nil {
+		editedAt = *existing.EditedAt
+	}
+	if item.ClientUpdatedAt.Equal(editedAt) {
+		result.Status = SyncUnchanged
+		return result, nil
+	}
+	if item.ClientUpdatedAt.Before(editedAt) {
+		return s.serverCopy(userID, result, SyncStale, &existing)
+	}
+
+	if item.Deleted {
+		// Photos go first so a storage failure leaves the check-in for a retry
+		if err := s.photos.DeleteCheckPhotos(existing.ID); err != nil {
+			return result, err
+		}
+		if err := s.db.Delete(&existing).Error; err != nil {
+			return result, err
+		}
+		if err := unlinkGratitudeEntry(s.db, existing.ID); err != nil {
+			return result, err
+		}
+		result.Status = SyncDeleted
+		return result, nil
+	}
+
+	return s.syncUpdate(userID, &existing, item)
+}
+
+func (s *FeelService) syncCreate(userID, id uuid.UUID, item *dto.SyncFeelCheckItem) (dto.SyncItemResult, error) {
+	result := dto.SyncItemResult{ID: id.String()}
+
+	checkDate, err := time.Parse("2006-01-02", item.CheckDate)
+	if err != nil {
+		result.Status, result.Message = SyncRejected, "check_date must be a date (YYYY-MM-DD)"
+		return result, nil
+	}
+	today := time.Now().Truncate(24 * time.Hour)
+	// A day ahead is allowed for time zones east of UTC
+	if checkDate.After(today.AddDate(0, 0, 1)) || checkDate.Before(today.AddDate(0, 0, -maxSyncAgeDays)) {
+		result.Status, result.Message = SyncRejected, fmt.Sprintf("check_date must be within the last %d days", maxSyncAgeDays)
+		return result, nil
+	}
+
+	var sameDay models.FeelCheck
+	err = s.db.Preload("Tags").Preload("Emotions").
+		Where("user_id = ? AND check_date = ?", userID, checkDate).
+		First(&sameDay).Error
+	if err == nil {
+		return s.serverCopy(userID, result, SyncDuplicateDate, &sameDay)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return result, err
+	}
+
+	check := &models.FeelCheck{ID: id, UserID: userID, CheckDate: checkDate}
+	if msg := s.applySyncFields(userID, check, item); msg != "" {
+		result.Status, result.Message = SyncRejected, msg
+		return result, nil
+	}
+
+	createdAt := item.ClientCreatedAt
+	if createdAt.IsZero() || createdAt.After(item.ClientUpdatedAt) {
+		createdAt = item.ClientUpdatedAt
+	}
+	check.CreatedAt = createdAt
+
+	if err := s.db.Omit("Tags.*").Create(check).Error; err != nil {
+		return result, err
+	}
+	if err := linkGratitudeEntry(s.db, userID, check.CheckDate, check.ID); err != nil {
+		return result, err
+	}
+
+	result.Status = SyncCreated
+	return result, nil
+}
+
+func (s *FeelService) syncUpdate(userID uuid.UUID, check *models.FeelCheck, item *dto.SyncFeelCheckItem) (dto.SyncItemResult, error) {
+	result := dto.SyncItemResult{ID: check.ID.String()}
+
+	if msg := s.applySyncFields(userID, check, item); msg != "" {
+		result.Status, result.Message = SyncRejected, msg
+		return result, nil
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(check).
+			Select("mood_score", "energy_score", "feel_score", "score_version", "color_hex",
+				"mood_emoji", "encrypted_note", "note", "prompt_id", "edited_at").
+			Updates(check).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(check).Omit("Tags.*").Association("Tags").Replace(check.Tags); err != nil {
+			return err
+		}
+		if err := tx.Where("feel_check_id = ?", check.ID).Delete(&models.FeelCheckEmotion{}).Error; err != nil {
+			return err
+		}
+		if len(check.Emotions) > 0 {
+			for i := range check.Emotions {
+				check.Emotions[i].FeelCheckID = check.ID
+			}
+			if err := tx.Create(&check.Emotions).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Status = SyncUpdated
+	return result, nil
+}
+
+// applySyncFields validates an item and copies it onto the check-in.
+// Returns a message for the client when the item is invalid.
+func (s *FeelService) applySyncFields(userID uuid.UUID, check *models.FeelCheck, item *dto.SyncFeelCheckItem) string {
+	if item.MoodScore < 1 || item.MoodScore > 100 || item.EnergyScore < 1 || item.EnergyScore > 100 {
+		return "scores must be between 1 and 100"
+	}
+	if utf8.RuneCountInString(item.Note) > maxNoteLength {
+		return "note must be at most 280 characters"
+	}
+
+	tags, err := findUsableTags(s.db, userID, item.TagIDs)
+	if err != nil {
+		return err.Error()
+	}
+	emotions, err := parseEmotions(item.EmotionIDs)
+	if err != nil {
+		return err.Error()
+	}
+	promptID, err := findPrompt(s.db, item.PromptID)
+	if err != nil {
+		return err.Error()
+	}
+
+	moodEmoji := strings.TrimSpace(item.MoodEmoji)
+	if moodEmoji == "" && len(emotions) > 0 {
+		emotion, _ := models.FindEmotion(emotions[0].EmotionID)
+		moodEmoji = emotion.DefaultEmoji
+	}
+	if len(moodEmoji) > 10 {
+		return "mood_emoji must be a single emoji"
+	}
+
+	encryptedNote, err := s.notes.Encrypt(userID, item.Note)
+	if err != nil {
+		return "failed to store note"
+	}
+
+	editedAt := item.ClientUpdatedAt
+	check.MoodScore = item.MoodScore
+	check.EnergyScore = item.EnergyScore
+	check.MoodEmoji = moodEmoji
+	check.Note = item.Note
+	check.EncryptedNote = encryptedNote
+	check.LegacyNote = ""
+	check.Tags = tags
+	check.Emotions = emotions
+	check.PromptID = promptID
+	check.EditedAt = &editedAt
+	check.CalculateFeelScore()
+	check.ColorHex = check.GetColorHex()
+	return ""
+}
+
+// serverCopy returns the stored check-in so the client can adopt it
+func (s *FeelService) serverCopy(userID uuid.UUID, result dto.SyncItemResult, status string, check *models.FeelCheck) (dto.SyncItemResult, error) {
+	checks := []models.FeelCheck{*check}
+	if err := s.notes.DecryptNotes(userID, checks); err != nil {
+		return result, err
+	}
+	result.Status = status
+	result.Check = &checks[0]
+	return result, nil
+}
+
+// encodeSyncToken makes an opaque token from a sync version
+func encodeSyncToken(version int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("v" + strconv.FormatInt(version, 10)))
+}
+
+// decodeSyncToken reverses encodeSyncToken; an empty token starts from the beginning
+func decodeSyncToken(token string) (int64, error) {
+	if token == "" {
+		return -1, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(raw), "v") {
+		return 0, ErrInvalidSyncToken
+	}
+	version, err := strconv.ParseInt(strings.TrimPrefix(string(raw), "v"), 10, 64)
+	if err != nil {
+		return 0, ErrInvalidSyncToken
+	}
+	return version, nil
+}
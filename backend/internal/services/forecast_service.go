@@ -0,0 +1,177 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	minForecastHistory = 14  // Check-ins needed before forecasting
+	forecastWindowDays = 120 // History the model is fitted on
+	forecastAccuracyN  = 30  // Past forecasts included in accuracy
+	forecastAlpha      = 0.3 // Level smoothing
+	forecastGamma      = 0.2 // Day-of-week smoothing
+	forecastZ          = 1.96
+)
+
+type ForecastService struct {
+	db *gorm.DB
+}
+
+func NewForecastService(db *gorm.DB) *ForecastService {
+	return &ForecastService{db: db}
+}
+
+// GetForecast forecasts tomorrow's feel and energy scores from the user's history
+// and stores the forecast for later evaluation
+func (s *ForecastService) GetForecast(userID uuid.UUID) (*dto.ForecastResponse, error) {
+	today := time.Now().Truncate(24 * time.Hour)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	var history []models.FeelCheck
+	err := s.db.Select("feel_score", "energy_score", "check_date").
+		Where("user_id = ? AND check_date > ? AND check_date <= ?", userID, today.AddDate(0, 0, -forecastWindowDays), today).
+		Order("check_date").
+		Find(&history).Error
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ForecastResponse{
+		CheckIns:         len(history),
+		RequiredCheckIns: minForecastHistory,
+	}
+	if len(history) < minForecastHistory {
+		resp.Status = dto.ForecastNotEnoughData
+		resp.Message = "Not enough data"
+		return resp, nil
+	}
+
+	feel := make([]float64, len(history))
+	energy := make([]float64, len(history))
+	weekdays := make([]time.Weekday, len(history))
+	for i, c := range history {
+		feel[i] = float64(c.FeelScore)
+		energy[i] = float64(c.EnergyScore)
+		weekdays[i] = c.CheckDate.Weekday()
+	}
+	feelForecast := smoothForecast(feel, weekdays, tomorrow.Weekday())
+	energyForecast := smoothForecast(energy, weekdays, tomorrow.Weekday())
+
+	forecast := models.MoodForecast{
+		UserID:       userID,
+		ForecastDate: tomorrow,
+		Method:       models.ForecastMethod,
+		HistorySize:  len(history),
+		FeelScore:    feelForecast.Value,
+		FeelLow:      feelForecast.Low,
+		FeelHigh:     feelForecast.High,
+		EnergyScore:  energyForecast.Value,
+		EnergyLow:    energyForecast.Low,
+		EnergyHigh:   energyForecast.High,
+	}
+	// Refreshed until the day starts; the last version is the one evaluated
+	err = s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "forecast_date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"method", "history_size", "feel_score", "feel_low", "feel_high",
+			"energy_score", "energy_low", "energy_high", "updated_at"}),
+	}).Create(&forecast).Error
+	if err != nil {
+		return nil, err
+	}
+
+	accuracy, err := s.accuracy(userID, today)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Status = dto.ForecastOK
+	resp.Date = tomorrow.Format("2006-01-02")
+	resp.Method = models.ForecastMethod
+	resp.FeelScore = &feelForecast
+	resp.EnergyScore = &energyForecast
+	resp.Accuracy = accuracy
+	return resp, nil
+}
+
+// accuracy records actual scores on past forecasts and summarizes the latest ones
+func (s *ForecastService) accuracy(userID uuid.UUID, today time.Time) (*dto.ForecastAccuracy, error) {
+	err := s.db.Exec(`
+		UPDATE mood_forecasts f
+		SET actual_feel_score = c.feel_score, actual_energy_score = c.energy_score
+		FROM feel_checks c
+		WHERE f.user_id = ? AND f.actual_feel_score IS NULL AND f.forecast_date <= ?
+			AND c.user_id = f.user_id AND c.check_date = f.forecast_date AND c.deleted_at IS NULL`,
+		userID, today).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var past []models.MoodForecast
+	err = s.db.Where("user_id = ? AND actual_feel_score IS NOT NULL", userID).
+		Order("forecast_date DESC").
+		Limit(forecastAccuracyN).
+		Find(&past).Error
+	if err != nil || len(past) == 0 {
+		return nil, err
+	}
+
+	var feelErr, energyErr float64
+	within := 0
+	for _, f := range past {
+		feelErr += math.Abs(float64(*f.ActualFeelScore) - f.FeelScore)
+		energyErr += math.Abs(float64(*f.ActualEnergyScore) - f.EnergyScore)
+		if actual := float64(*f.ActualFeelScore); actual >= f.FeelLow && actual <= f.FeelHigh {
+			within++
+		}
+	}
+	n := float64(len(past))
+	return &dto.ForecastAccuracy{
+		Evaluated:     len(past),
+		FeelMAE:       round1(feelErr / n),
+		EnergyMAE:     round1(energyErr / n),
+		FeelWithinPct: round1(float64(within) / n * 100),
+	}, nil
+}
+
+// smoothForecast fits exponential smoothing with additive day-of-week seasonality
+// over the observed check-ins and forecasts the next value for the given weekday.
+// The band is ±1.96 times the RMSE of one-step-ahead errors after a week of warm-up.
+func smoothForecast(values []float64, weekdays []time.Weekday, next time.Weekday) dto.ForecastValue {
+	var season [7]float64
+	level := values[0]
+	var sqErr float64
+	errCount := 0
+
+	for i := 1; i < len(values); i++ {
+		wd := weekdays[i]
+		if e := values[i] - (level + season[wd]); i >= 7 {
+			sqErr += e * e
+			errCount++
+		}
+		prevLevel := level
+		level += forecastAlpha * (values[i] - season[wd] - level)
+		season[wd] += forecastGamma * (values[i] - prevLevel - season[wd])
+	}
+
+	value := level + season[next]
+	spread := 0.0
+	if errCount > 0 {
+		spread = forecastZ * math.Sqrt(sqErr/float64(errCount))
+	}
+	return dto.ForecastValue{
+		Value: round1(clampScore(value)),
+		Low:   round1(clampScore(value - spread)),
+		High:  round1(clampScore(value + spread)),
+	}
+}
+
+func clampScore(v float64) float64 {
+	return math.Max(1, math.Min(100, v))
+}
@@ -0,0 +1,405 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxActiveGoals = 10
+
+var ErrGoalNotFound = errors.New("goal not found")
+
+// goalBadges are unlocked by the total number of completed goals
+var goalBadges = []struct {
+	Badge       string
+	Completions int64
+}{
+	{"goal_1", 1},
+	{"goal_10", 10},
+	{"goal_50", 50},
+}
+
+type GoalService struct {
+	db *gorm.DB
+}
+
+func NewGoalService(db *gorm.DB) *GoalService {
+	return &GoalService{db: db}
+}
+
+// ListGoals returns the user's goals with progress for the current period
+func (s *GoalService) ListGoals(userID uuid.UUID) ([]dto.GoalResponse, error) {
+	var goals []models.Goal
+	if err := s.db.Where("user_id = ?", userID).Order("is_active DESC, created_at").Find(&goals).Error; err != nil {
+		return nil, err
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	resp := make([]dto.GoalResponse, 0, len(goals))
+	for i := range goals {
+		resp = append(resp, goalResponse(&goals[i], today))
+	}
+	return resp, nil
+}
+
+func (s *GoalService) CreateGoal(userID uuid.UUID, req *dto.CreateGoalRequest) (*dto.GoalResponse, error) {
+	if err := validateGoal(req.Type, req.Period, req.Target); err != nil {
+		return nil, err
+	}
+
+	var active int64
+	if err := s.db.Model(&models.Goal{}).Where("user_id = ? AND is_active = ?", userID, true).Count(&active).Error; err != nil {
+		return nil, err
+	}
+	if active >= maxActiveGoals {
+		return nil, errors.New("you can have at most 10 active goals")
+	}
+
+	goal := models.Goal{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Type:     req.Type,
+		Target:   req.Target,
+		Period:   req.Period,
+		Title:    strings.TrimSpace(req.Title),
+		IsActive: true,
+	}
+	if utf8.RuneCountInString(goal.Title) > 100 {
+		return nil, errors.New("title must be at most 100 characters")
+	}
+
+	if err := s.db.Create(&goal).Error; err != nil {
+		return nil, err
+	}
+
+	// Count check-ins already made this period
+	if _, err := s.evaluate(&goal, time.Now().Truncate(24*time.Hour)); err != nil {
+		return nil, err
+	}
+
+	resp := goalResponse(&goal, time.Now().Truncate(24*time.Hour))
+	return &resp, nil
+}
+
+func (s *GoalService) UpdateGoal(userID, goalID uuid.UUID, req *dto.UpdateGoalRequest) (*dto.GoalResponse, error) {
+	goal, err := s.ownGoal(userID, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if utf8.RuneCountInString(title) > 100 {
+			return nil, errors.New("title must be at most 100 characters")
+		}
+		goal.Title = title
+	}
+	if req.Target != nil {
+		if err := validateGoal(goal.Type, goal.Period, *req.Target); err != nil {
+			return nil, err
+		}
+		goal.Target = *req.Target
+	}
+	if req.IsActive != nil && *req.IsActive && !goal.IsActive {
+		var active int64
+		if err := s.db.Model(&models.Goal{}).Where("user_id = ? AND is_active = ?", userID, true).Count(&active).Error; err != nil {
+			return nil, err
+		}
+		if active >= maxActiveGoals {
+			return nil, errors.New("you can have at most 10 active goals")
+		}
+	}
+	if req.IsActive != nil {
+		goal.IsActive = *req.IsActive
+	}
+
+	if err := s.db.Save(goal).Error; err != nil {
+		return nil, err
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	if goal.IsActive {
+		if _, err := s.evaluate(goal, today); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := goalResponse(goal, today)
+	return &resp, nil
+}
+
+// DeleteGoal removes a goal; its completion history is kept
+func (s *GoalService) DeleteGoal(userID, goalID uuid.UUID) error {
+	goal, err := s.ownGoal(userID, goalID)
+	if err != nil {
+		return err
+	}
+	return s.db.Delete(goal).Error
+}
+
+// ListCompletions returns completed goal history and marks unseen entries as seen
+func (s *GoalService) ListCompletions(userID uuid.UUID, limit int) (*dto.GoalCompletionsResponse, error) {
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+
+	var completions []models.GoalCompletion
+	err := s.db.Where("user_id = ?", userID).
+		Order("completed_at DESC").
+		Limit(limit).
+		Find(&completions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var unseen []uuid.UUID
+	for _, c := range completions {
+		if c.SeenAt == nil {
+			unseen = append(unseen, c.ID)
+		}
+	}
+	if len(unseen) > 0 {
+		err := s.db.Model(&models.GoalCompletion{}).
+			Where("id IN ? AND seen_at IS NULL", unseen).
+			Update("seen_at", time.Now()).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.GoalCompletionsResponse{Data: completions, Unseen: len(unseen)}, nil
+}
+
+// EvaluateGoals updates progress on the user's active goals after a check-in.
+// Only the current period is recomputed, and newly met goals are recorded once
+// per period and count towards goal badges.
+func (s *GoalService) EvaluateGoals(userID uuid.UUID) error {
+	var goals []models.Goal
+	if err := s.db.Where("user_id = ? AND is_active = ?", userID, true).Find(&goals).Error; err != nil {
+		return err
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	completed := false
+	for i := range goals {
+		done, err := s.evaluate(&goals[i], today)
+		if err != nil {
+			return err
+		}
+		completed = completed || done
+	}
+
+	if completed {
+		return s.unlockGoalBadges(userID)
+	}
+	return nil
+}
+
+// evaluate recomputes a goal's progress for the period containing today and
+// reports whether it was newly completed
+func (s *GoalService) evaluate(goal *models.Goal, today time.Time) (bool, error) {
+	start, end := goalPeriod(goal.Period, today)
+
+	var stats struct {
+		Days     int
+		AvgScore *float64
+	}
+	err := s.db.Model(&models.FeelCheck{}).
+		Select("COUNT(DISTINCT check_date) AS days, AVG(feel_score) AS avg_score").
+		Where("user_id = ? AND check_date BETWEEN ? AND ?", goal.UserID, start, end).
+		Scan(&stats).Error
+	if err != nil {
+		return false, err
+	}
+
+	var progress float64
+	achieved := false
+	switch goal.Type {
+	case models.GoalCheckInDays:
+		progress = float64(stats.Days)
+		achieved = stats.Days >= goal.Target
+	case models.GoalAvgScore:
+		if stats.AvgScore != nil {
+			progress = round1(*stats.AvgScore)
+		}
+		// An average only counts once at least half the period has check-ins
+		periodDays := int(end.Sub(start).Hours()/24) + 1
+		achieved = stats.Days*2 >= periodDays && progress >= float64(goal.Target)
+	case models.GoalStreakDays:
+		longest, err := s.longestRun(goal.UserID, start, end)
+		if err != nil {
+			return false, err
+		}
+		progress = float64(longest)
+		achieved = longest >= goal.Target
+	}
+
+	updates := map[string]interface{}{"progress": progress, "period_start": start}
+	samePeriod := goal.PeriodStart != nil && goal.PeriodStart.Equal(start)
+	if !samePeriod {
+		updates["completed_at"] = nil
+		goal.CompletedAt = nil
+	}
+
+	newlyCompleted := false
+	if achieved && goal.CompletedAt == nil {
+		now := time.Now()
+		completion := models.GoalCompletion{
+			GoalID:      goal.ID,
+			UserID:      goal.UserID,
+			GoalType:    goal.Type,
+			Target:      goal.Target,
+			Value:       progress,
+			PeriodStart: start,
+			PeriodEnd:   end,
+			CompletedAt: now,
+		}
+		result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&completion)
+		if result.Error != nil {
+			return false, result.Error
+		}
+		newlyCompleted = result.RowsAffected > 0
+		updates["completed_at"] = now
+		goal.CompletedAt = &now
+	}
+
+	if err := s.db.Model(goal).Updates(updates).Error; err != nil {
+		return false, err
+	}
+	goal.Progress = progress
+	goal.PeriodStart = &start
+
+	return newlyCompleted, nil
+}
+
+// longestRun finds the longest run of consecutive check-in days within [start, end]
+func (s *GoalService) longestRun(userID uuid.UUID, start, end time.Time) (int, error) {
+	var longest int
+	err := s.db.Raw(`
+		WITH days AS (
+			SELECT DISTINCT check_date
+			FROM feel_checks
+			WHERE user_id = ? AND deleted_at IS NULL AND check_date BETWEEN ? AND ?
+		)
+		SELECT COALESCE(MAX(days), 0) FROM (
+			SELECT COUNT(*) AS days
+			FROM (
+				SELECT check_date - (ROW_NUMBER() OVER (ORDER BY check_date))::int AS grp
+				FROM days
+			) islands
+			GROUP BY grp
+		) runs`, userID, start, end).
+		Scan(&longest).Error
+	return longest, err
+}
+
+// unlockGoalBadges adds goal badges the user has reached to their streak record
+func (s *GoalService) unlockGoalBadges(userID uuid.UUID) error {
+	var total int64
+	if err := s.db.Model(&models.GoalCompletion{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, b := range goalBadges {
+		if total < b.Completions {
+			continue
+		}
+
+		unlock := models.BadgeUnlock{UserID: userID, Badge: b.Badge, UnlockedAt: now}
+		result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&unlock)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		err := s.db.Exec(`UPDATE feel_streaks SET unlocked_badges = array_append(unlocked_badges, ?)
+			WHERE user_id = ? AND NOT (? = ANY(unlocked_badges))`, b.Badge, userID, b.Badge).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *GoalService) ownGoal(userID, goalID uuid.UUID) (*models.Goal, error) {
+	var goal models.Goal
+	if err := s.db.Where("id = ? AND user_id = ?", goalID, userID).First(&goal).Error; err != nil {
+		return nil, ErrGoalNotFound
+	}
+	return &goal, nil
+}
+
+func validateGoal(goalType, period string, target int) error {
+	if period != models.GoalWeekly && period != models.GoalMonthly {
+		return errors.New("period must be week or month")
+	}
+
+	maxDays := 7
+	if period == models.GoalMonthly {
+		maxDays = 28 // Every month has at least 28 days
+	}
+
+	switch goalType {
+	case models.GoalCheckInDays:
+		if target < 1 || target > maxDays {
+			return errors.New("target days must fit in the period")
+		}
+	case models.GoalStreakDays:
+		if target < 2 || target > maxDays {
+			return errors.New("target streak must be at least 2 days and fit in the period")
+		}
+	case models.GoalAvgScore:
+		if target < 1 || target > 100 {
+			return errors.New("target score must be between 1 and 100")
+		}
+	default:
+		return errors.New("type must be check_in_days, avg_score or streak_days")
+	}
+	return nil
+}
+
+// goalPeriod returns the first and last day of the week (Monday start) or month containing today
+func goalPeriod(period string, today time.Time) (time.Time, time.Time) {
+	if period == models.GoalMonthly {
+		start := today.AddDate(0, 0, 1-today.Day())
+		return start, start.AddDate(0, 1, -1)
+	}
+	start := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	return start, start.AddDate(0, 0, 6)
+}
+
+func goalResponse(goal *models.Goal, today time.Time) dto.GoalResponse {
+	start, end := goalPeriod(goal.Period, today)
+	resp := dto.GoalResponse{
+		ID:          goal.ID.String(),
+		Type:        goal.Type,
+		Title:       goal.Title,
+		Target:      goal.Target,
+		Period:      goal.Period,
+		IsActive:    goal.IsActive,
+		PeriodStart: start.Format("2006-01-02"),
+		PeriodEnd:   end.Format("2006-01-02"),
+	}
+
+	// Progress from an earlier period does not carry over
+	if goal.PeriodStart != nil && goal.PeriodStart.Equal(start) {
+		resp.Progress = goal.Progress
+		resp.Completed = goal.CompletedAt != nil
+		if goal.Target > 0 {
+			resp.Percent = int(math.Min(100, math.Round(goal.Progress/float64(goal.Target)*100)))
+		}
+	}
+	return resp
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxGratitudeItemLength = 140
+
+var (
+	ErrGratitudeNotFound   = errors.New("gratitude entry not found")
+	ErrInvalidGratitudeDay = errors.New("date must be YYYY-MM-DD and not in the future")
+)
+
+type GratitudeService struct {
+	db         *gorm.DB
+	notes      *NoteCipher
+	moderation *ModerationService
+}
+
+func NewGratitudeService(db *gorm.DB, notes *NoteCipher, moderation *ModerationService) *GratitudeService {
+	return &GratitudeService{db: db, notes: notes, moderation: moderation}
+}
+
+// SaveEntry replaces the user's gratitude list for a day and links it to that
+// day's check-in when there is one
+func (s *GratitudeService) SaveEntry(userID uuid.UUID, req *dto.SaveGratitudeRequest) (*dto.GratitudeEntryResponse, error) {
+	date, err := parseGratitudeDate(req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]string, 0, len(req.Items))
+	for _, item := range req.Items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if utf8.RuneCountInString(item) > maxGratitudeItemLength {
+			return nil, fmt.Errorf("items must be at most %d characters", maxGratitudeItemLength)
+		}
+		if ok, reason := s.moderation.FilterContent(item); !ok {
+			return nil, errors.New(reason)
+		}
+		items = append(items, item)
+	}
+	if len(items) == 0 || len(items) > models.MaxGratitudeItems {
+		return nil, fmt.Errorf("between 1 and %d items are required", models.MaxGratitudeItems)
+	}
+
+	raw, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := s.notes.Encrypt(userID, string(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	var checkIDs []uuid.UUID
+	err = s.db.Model(&models.FeelCheck{}).
+		Where("user_id = ? AND check_date = ?", userID, date).
+		Order("created_at").Limit(1).
+		Pluck("id", &checkIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	entry := models.GratitudeEntry{
+		UserID:         userID,
+		EntryDate:      date,
+		EncryptedItems: encrypted,
+		ItemCount:      len(items),
+	}
+	if len(checkIDs) > 0 {
+		entry.FeelCheckID = &checkIDs[0]
+	}
+	err = s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "entry_date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"encrypted_items", "item_count", "feel_check_id", "updated_at"}),
+	}).Create(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Read back for the ID and created_at of an existing entry
+	if err := s.db.Where("user_id = ? AND entry_date = ?", userID, date).First(&entry).Error; err != nil {
+		return nil, err
+	}
+	entry.Items = items
+	return gratitudeResponse(&entry), nil
+}
+
+// GetHistory returns a keyset-paginated page of gratitude entries, newest first
+func (s *GratitudeService) GetHistory(userID uuid.UUID, req *dto.GratitudeHistoryQuery) (*dto.GratitudeHistoryResponse, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = 20
+	}
+	if limit < 1 || limit > 100 {
+		return nil, errors.New("limit must be between 1 and 100")
+	}
+
+	query := s.db.Where("user_id = ?", userID)
+	if req.From != "" {
+		from, err := time.Parse("2006-01-02", req.From)
+		if err != nil {
+			return nil, errors.New("from must be a date (YYYY-MM-DD)")
+		}
+		query = query.Where("entry_date >= ?", from)
+	}
+	if req.To != "" {
+		to, err := time.Parse("2006-01-02", req.To)
+		if err != nil {
+			return nil, errors.New("to must be a date (YYYY-MM-DD)")
+		}
+		query = query.Where("entry_date <= ?", to)
+	}
+	if req.Cursor != "" {
+		cursorDate, cursorID, err := decodeCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("(entry_date, id) < (?, ?)", cursorDate, cursorID)
+	}
+
+	var entries []models.GratitudeEntry
+	if err := query.Order("entry_date DESC, id DESC").Limit(limit + 1).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	resp := &dto.GratitudeHistoryResponse{Limit: limit}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		last := entries[len(entries)-1]
+		resp.HasMore = true
+		resp.NextCursor = encodeCursor(last.EntryDate, last.ID)
+	}
+	if err := s.notes.DecryptGratitude(userID, entries); err != nil {
+		return nil, err
+	}
+
+	resp.Data = make([]dto.GratitudeEntryResponse, 0, len(entries))
+	for i := range entries {
+		resp.Data = append(resp.Data, *gratitudeResponse(&entries[i]))
+	}
+	return resp, nil
+}
+
+// DeleteEntry removes the user's gratitude list for a day
+func (s *GratitudeService) DeleteEntry(userID uuid.UUID, rawDate string) error {
+	date, err := time.Parse("2006-01-02", rawDate)
+	if err != nil {
+		return ErrInvalidGratitudeDay
+	}
+	result := s.db.Where("user_id = ? AND entry_date = ?", userID, date).Delete(&models.GratitudeEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrGratitudeNotFound
+	}
+	return nil
+}
+
+// GetStreak returns the gratitude streak using gaps-and-islands over entry
+// days. Like check-in streaks, it stays current until a full day is missed.
+func (s *GratitudeService) GetStreak(userID uuid.UUID) (*dto.GratitudeStreakResponse, error) {
+	var islands []struct {
+		StartDate time.Time
+		EndDate   time.Time
+		Days      int
+	}
+	err := s.db.Raw(`
+		WITH islands AS (
+			SELECT entry_date AS day, entry_date - (ROW_NUMBER() OVER (ORDER BY entry_date))::int AS grp
+			FROM gratitude_entries
+			WHERE user_id = ?
+		)
+		SELECT MIN(day) AS start_date, MAX(day) AS end_date, COUNT(*) AS days
+		FROM islands
+		GROUP BY grp
+		ORDER BY end_date`, userID).
+		Scan(&islands).Error
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.GratitudeStreakResponse{}
+	if len(islands) == 0 {
+		return resp, nil
+	}
+
+	for _, island := range islands {
+		resp.TotalDays += island.Days
+		if island.Days > resp.LongestStreak {
+			resp.LongestStreak = island.Days
+		}
+	}
+
+	last := islands[len(islands)-1]
+	lastDate := last.EndDate.Format("2006-01-02")
+	resp.LastEntryDate = &lastDate
+	today := time.Now().Truncate(24 * time.Hour)
+	if !last.EndDate.Before(today.AddDate(0, 0, -1)) {
+		resp.CurrentStreak = last.Days
+	}
+	return resp, nil
+}
+
+// linkGratitudeEntry attaches the day's gratitude entry to a newly created check-in
+func linkGratitudeEntry(db *gorm.DB, userID uuid.UUID, checkDate time.Time, checkID uuid.UUID) error {
+	return db.Model(&models.GratitudeEntry{}).
+		Where("user_id = ? AND entry_date = ? AND feel_check_id IS NULL", userID, checkDate).
+		UpdateColumn("feel_check_id", checkID).Error
+}
+
+// unlinkGratitudeEntry detaches gratitude entries from a deleted check-in
+func unlinkGratitudeEntry(db *gorm.DB, checkID uuid.UUID) error {
+	return db.Model(&models.GratitudeEntry{}).
+		Where("feel_check_id = ?", checkID).
+		UpdateColumn("feel_check_id", nil).Error
+}
+
+// parseGratitudeDate defaults to today and allows a day ahead for time zones east of UTC
+func parseGratitudeDate(raw string) (time.Time, error) {
+	today := time.Now().Truncate(24 * time.Hour)
+	if raw == "" {
+		return today, nil
+	}
+	date, err := time.Parse("2006-01-02", raw)
+	if err != nil || date.After(today.AddDate(0, 0, 1)) {
+		return time.Time{}, ErrInvalidGratitudeDay
+	}
+	return date, nil
+}
+
+func gratitudeResponse(entry *models.GratitudeEntry) *dto.GratitudeEntryResponse {
+	resp := &dto.GratitudeEntryResponse{
+		ID:        entry.ID.String(),
+		Date:      entry.EntryDate.Format("2006-01-02"),
+		Items:     entry.Items,
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+	}
+	if resp.Items == nil {
+		resp.Items = []string{}
+	}
+	if entry.FeelCheckID != nil {
+		id := entry.FeelCheckID.String()
+		resp.FeelCheckID = &id
+	}
+	return resp
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	importProgressEvery = time.Second      // How often parsing progress is saved
+	importStaleAfter    = 10 * time.Minute // A processing import with no progress for this long is retried
+)
+
+var (
+	ErrImportNotFound    = errors.New("import not found")
+	ErrInvalidImportFile = errors.New("file must be an Apple Health export (.xml or .zip) or a .csv")
+)
+
+type HealthImportService struct {
+	db   *gorm.DB
+	dir  string
+	wake chan struct{}
+}
+
+// NewHealthImportService stores uploads in dir until they are parsed. With
+// several server instances dir must be shared storage.
+func NewHealthImportService(db *gorm.DB, dir string) (*HealthImportService, error) {
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "feelsy-imports")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create import directory: %w", err)
+	}
+	return &HealthImportService{db: db, dir: dir, wake: make(chan struct{}, 1)}, nil
+}
+
+// CreateImport stores an uploaded export and queues it for parsing
+func (s *HealthImportService) CreateImport(userID uuid.UUID, file *multipart.FileHeader) (*models.HealthImport, error) {
+	var source string
+	switch strings.ToLower(filepath.Ext(file.Filename)) {
+	case ".xml", ".zip":
+		source = models.HealthSourceAppleHealth
+	case ".csv":
+		source = models.HealthSourceCSV
+	default:
+		return nil, ErrInvalidImportFile
+	}
+
+	id := uuid.New()
+	dst := filepath.Join(s.dir, id.String())
+	if err := saveUpload(file, dst); err != nil {
+		return nil, err
+	}
+
+	job := &models.HealthImport{
+		ID:         id,
+		UserID:     userID,
+		Source:     source,
+		Status:     models.ImportPending,
+		FileName:   filepath.Base(file.Filename),
+		FilePath:   dst,
+		TotalBytes: file.Size,
+	}
+	if err := s.db.Create(job).Error; err != nil {
+		os.Remove(dst)
+		return nil, err
+	}
+
+	// Start right away instead of waiting for the next poll
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// GetImport returns one of the user's imports with its progress
+func (s *HealthImportService) GetImport(userID, importID uuid.UUID) (*models.HealthImport, error) {
+	var job models.HealthImport
+	err := s.db.Where("id = ? AND user_id = ?", importID, userID).First(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrImportNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListImports returns the user's recent imports, newest first
+func (s *HealthImportService) ListImports(userID uuid.UUID) ([]models.HealthImport, error) {
+	jobs := []models.HealthImport{}
+	err := s.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(20).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// RunWorker parses queued imports one at a time until ctx is done, checking
+// every interval and whenever a new import is uploaded
+func (s *HealthImportService) RunWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			job, err := s.claimNext()
+			if err != nil {
+				log.Printf("Health import claim failed: %v", err)
+				break
+			}
+			if job == nil {
+				break
+			}
+			s.process(ctx, job)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// claimNext marks the oldest pending import, or one abandoned by a stopped
+// worker, as processing. SKIP LOCKED keeps concurrent workers apart.
+func (s *HealthImportService) claimNext() (*models.HealthImport, error) {
+	now := time.Now()
+	var job models.HealthImport
+	err := s.db.Raw(`
+		UPDATE health_imports SET status = ?, started_at = ?, updated_at = ?
+		WHERE id = (
+			SELECT id FROM health_imports
+			WHERE status = ? OR (status = ? AND updated_at < ?)
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		models.ImportProcessing, now, now, models.ImportPending, models.ImportProcessing, now.Add(-importStaleAfter)).
+		Scan(&job).Error
+	if err != nil || job.ID == uuid.Nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// process parses an import, saves its daily metrics and records the outcome
+func (s *HealthImportService) process(ctx context.Context, job *models.HealthImport) {
+	agg := newHealthAggregator()
+	err := s.parse(ctx, job, agg)
+	if err == nil {
+		job.DaysImported, err = s.saveMetrics(job.UserID, job.Source, agg)
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"records_read":  agg.records,
+		"days_imported": job.DaysImported,
+		"completed_at":  now,
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return // Shutting down; another run picks it up once it goes stale
+		}
+		msg := err.Error()
+		if len(msg) > 500 {
+			msg = msg[:500]
+		}
+		updates["status"], updates["error"] = models.ImportFailed, msg
+		log.Printf("Health import %s failed: %v", job.ID, err)
+	} else {
+		updates["status"], updates["progress"], updates["processed_bytes"] = models.ImportCompleted, 100, job.TotalBytes
+	}
+
+	if err := s.db.Model(&models.HealthImport{}).Where("id = ?", job.ID).Updates(updates).Error; err != nil {
+		log.Printf("Failed to update health import %s: %v", job.ID, err)
+		return
+	}
+	os.Remove(job.FilePath)
+}
+
+// parse streams the stored file through the parser for its source
+func (s *HealthImportService) parse(ctx context.Context, job *models.HealthImport, agg *healthAggregator) error {
+	file, err := os.Open(job.FilePath)
+	if err != nil {
+		return fmt.Errorf("upload is no longer available: %w", err)
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	total := job.TotalBytes
+	if job.Source == models.HealthSourceAppleHealth && strings.EqualFold(filepath.Ext(job.FileName), ".zip") {
+		entry, err := findExportXML(file, job.TotalBytes)
+		if err != nil {
+			return err
+		}
+		rc, err := entry.Open()
+		if err != nil {
+			return fmt.Errorf("invalid zip file: %w", err)
+		}
+		defer rc.Close()
+		r, total = rc, int64(entry.UncompressedSize64)
+	}
+
+	progress := &progressReader{r: r, ctx: ctx, report: func(read int64) {
+		pct := 0
+		if total > 0 {
+			pct = int(read * 99 / total) // 100 only once metrics are saved
+		}
+		s.db.Model(&models.HealthImport{}).Where("id = ?", job.ID).
+			Updates(map[string]interface{}{"processed_bytes": read, "progress": pct})
+	}}
+
+	if job.Source == models.HealthSourceCSV {
+		return parseHealthCSV(progress, agg)
+	}
+	return parseAppleHealth(progress, agg)
+}
+
+// saveMetrics upserts the parsed days; metrics missing from this import keep their stored values
+func (s *HealthImportService) saveMetrics(userID uuid.UUID, source string, agg *healthAggregator) (int, error) {
+	rows := agg.metrics(userID, source)
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	err := s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "date"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"sleep_hours":        gorm.Expr("COALESCE(EXCLUDED.sleep_hours, daily_metrics.sleep_hours)"),
+			"steps":              gorm.Expr("COALESCE(EXCLUDED.steps, daily_metrics.steps)"),
+			"resting_heart_rate": gorm.Expr("COALESCE(EXCLUDED.resting_heart_rate, daily_metrics.resting_heart_rate)"),
+			"source":             gorm.Expr("EXCLUDED.source"),
+			"updated_at":         gorm.Expr("EXCLUDED.updated_at"),
+		}),
+	}).CreateInBatches(rows, 500).Error
+	return len(rows), err
+}
+
+// findExportXML locates export.xml inside an Apple Health export.zip
+func findExportXML(file *os.File, size int64) (*zip.File, error) {
+	archive, err := zip.NewReader(file, size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip file: %w", err)
+	}
+	for _, f := range archive.File {
+		if path.Base(f.Name) == "export.xml" {
+			return f, nil
+		}
+	}
+	return nil, errors.New("zip file does not contain export.xml")
+}
+
+// saveUpload copies an uploaded file to dst
+func saveUpload(file *multipart.FileHeader, dst string) error {
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, src); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
+
+// progressReader counts bytes read and reports them at most every importProgressEvery.
+// It stops the parse when ctx is done.
+type progressReader struct {
+	r      io.Reader
+	ctx    context.Context
+	read   int64
+	last   time.Time
+	report func(read int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	if err := p.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if time.Since(p.last) >= importProgressEvery {
+		p.last = time.Now()
+		p.report(p.read)
+	}
+	return n, err
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// Apple Health record types that are imported
+const (
+	hkStepCount        = "HKQuantityTypeIdentifierStepCount"
+	hkRestingHeartRate = "HKQuantityTypeIdentifierRestingHeartRate"
+	hkSleepAnalysis    = "HKCategoryTypeIdentifierSleepAnalysis"
+	hkAsleepPrefix     = "HKCategoryValueSleepAnalysisAsleep" // Asleep, AsleepCore, AsleepDeep, AsleepREM...
+	hkDateLayout       = "2006-01-02 15:04:05 -0700"
+)
+
+// healthDay accumulates one day's metrics while parsing
+type healthDay struct {
+	steps   map[string]float64 // By source, so phone and watch are not counted twice
+	sleep   map[string]float64 // Hours by source
+	hrSum   float64
+	hrCount int
+}
+
+// healthAggregator collects daily metrics from a streamed export
+type healthAggregator struct {
+	days    map[string]*healthDay // Keyed by YYYY-MM-DD
+	records int
+}
+
+func newHealthAggregator() *healthAggregator {
+	return &healthAggregator{days: make(map[string]*healthDay)}
+}
+
+func (a *healthAggregator) day(date string) *healthDay {
+	d, ok := a.days[date]
+	if !ok {
+		d = &healthDay{steps: map[string]float64{}, sleep: map[string]float64{}}
+		a.days[date] = d
+	}
+	return d
+}
+
+// metrics turns the aggregated days into rows, taking the source with the most
+// steps and sleep per day and dropping implausible values
+func (a *healthAggregator) metrics(userID uuid.UUID, source string) []models.DailyMetric {
+	rows := make([]models.DailyMetric, 0, len(a.days))
+	for date, d := range a.days {
+		day, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			continue
+		}
+		row := models.DailyMetric{UserID: userID, Date: day, Source: source}
+		if steps := maxBySource(d.steps); steps > 0 && steps <= 200000 {
+			n := int(math.Round(steps))
+			row.Steps = &n
+		}
+		if sleep := maxBySource(d.sleep); sleep > 0 && sleep <= 24 {
+			hours := math.Round(sleep*100) / 100
+			row.SleepHours = &hours
+		}
+		if d.hrCount > 0 {
+			if hr := d.hrSum / float64(d.hrCount); hr >= 20 && hr <= 250 {
+				hr = round1(hr)
+				row.RestingHeartRate = &hr
+			}
+		}
+		if row.Steps != nil || row.SleepHours != nil || row.RestingHeartRate != nil {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func maxBySource(values map[string]float64) float64 {
+	max := 0.0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+// parseAppleHealth streams an Apple Health export.xml, reading only the
+// <Record> elements for steps, sleep and resting heart rate
+func parseAppleHealth(r io.Reader, agg *healthAggregator) error {
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid export.xml: %w", err)
+		}
+		el, ok := tok.(xml.StartElement)
+		if !ok || el.Name.Local != "Record" {
+			continue
+		}
+
+		var recordType, source, value, start, end string
+		for _, attr := range el.Attr {
+			switch attr.Name.Local {
+			case "type":
+				recordType = attr.Value
+			case "sourceName":
+				source = attr.Value
+			case "value":
+				value = attr.Value
+			case "startDate":
+				start = attr.Value
+			case "endDate":
+				end = attr.Value
+			}
+		}
+		if len(start) < 10 || len(end) < 10 {
+			continue
+		}
+
+		switch recordType {
+		case hkStepCount:
+			steps, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			agg.day(start[:10]).steps[source] += steps
+		case hkRestingHeartRate:
+			bpm, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			d := agg.day(start[:10])
+			d.hrSum += bpm
+			d.hrCount++
+		case hkSleepAnalysis:
+			if !strings.HasPrefix(value, hkAsleepPrefix) {
+				continue // In bed or awake
+			}
+			from, err1 := time.Parse(hkDateLayout, start)
+			to, err2 := time.Parse(hkDateLayout, end)
+			if err1 != nil || err2 != nil || !to.After(from) {
+				continue
+			}
+			// Sleep counts toward the day the user woke up
+			agg.day(end[:10]).sleep[source] += to.Sub(from).Hours()
+		default:
+			continue
+		}
+		agg.records++
+	}
+}
+
+// healthCSVColumns maps accepted header names to metrics
+var healthCSVColumns = map[string]string{
+	"date":               "date",
+	"day":                "date",
+	"sleep_hours":        "sleep",
+	"sleep":              "sleep",
+	"hours_slept":        "sleep",
+	"steps":              "steps",
+	"step_count":         "steps",
+	"resting_heart_rate": "hr",
+	"resting_hr":         "hr",
+	"rhr":                "hr",
+}
+
+// parseHealthCSV streams a CSV with a header row and one row per day
+func parseHealthCSV(r io.Reader, agg *healthAggregator) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return errors.New("CSV file is empty")
+	}
+	if err != nil {
+		return fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		if metric, ok := healthCSVColumns[normalizeColumn(name)]; ok {
+			columns[metric] = i
+		}
+	}
+	if _, ok := columns["date"]; !ok {
+		return errors.New("CSV needs a date column")
+	}
+	if len(columns) == 1 {
+		return errors.New("CSV needs a sleep_hours, steps or resting_heart_rate column")
+	}
+
+	cell := func(record []string, metric string) string {
+		i, ok := columns[metric]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		date := cell(record, "date")
+		if len(date) < 10 {
+			return fmt.Errorf("line %d: date must be YYYY-MM-DD", line)
+		}
+		if _, err := time.Parse("2006-01-02", date[:10]); err != nil {
+			return fmt.Errorf("line %d: date must be YYYY-MM-DD", line)
+		}
+		d := agg.day(date[:10])
+
+		for _, metric := range []string{"sleep", "steps", "hr"} {
+			raw := cell(record, metric)
+			if raw == "" {
+				continue
+			}
+			v, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return fmt.Errorf("line %d: %q is not a number", line, raw)
+			}
+			switch metric {
+			case "sleep":
+				d.sleep[""] += v
+			case "steps":
+				d.steps[""] += v
+			case "hr":
+				d.hrSum += v
+				d.hrCount++
+			}
+		}
+		agg.records++
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// idempotencyLockTimeout is how long an unfinished request holds its key.
+	// After that the request is assumed lost and a retry may run again.
+	idempotencyLockTimeout = time.Minute
+	idempotencyWait        = 10 * time.Second
+	idempotencyPoll        = 100 * time.Millisecond
+)
+
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used for a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still in progress")
+)
+
+type IdempotencyService struct {
+	db     *gorm.DB
+	cipher *NoteCipher
+	ttl    time.Duration
+}
+
+func NewIdempotencyService(db *gorm.DB, cipher *NoteCipher, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{db: db, cipher: cipher, ttl: ttl}
+}
+
+// HashRequest fingerprints a request so a reused key can be told apart from a retry
+func HashRequest(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Begin claims the key for a request. It returns (nil, nil) when the caller owns
+// the key and should run the request, or the stored record to replay. A duplicate
+// that arrives while the first request is running waits for it to finish.
+func (s *IdempotencyService) Begin(ctx context.Context, userID uuid.UUID, key, requestHash string) (*models.IdempotencyKey, error) {
+	deadline := time.Now().Add(idempotencyWait)
+	for {
+		now := time.Now()
+
+		// Expired keys and abandoned claims can be taken over
+		err := s.db.Where("user_id = ? AND key = ? AND (expires_at < ? OR (completed_at IS NULL AND created_at < ?))",
+			userID, key, now, now.Add(-idempotencyLockTimeout)).
+			Delete(&models.IdempotencyKey{}).Error
+		if err != nil {
+			return nil, err
+		}
+
+		claim := models.IdempotencyKey{
+			UserID:      userID,
+			Key:         key,
+			RequestHash: requestHash,
+			ExpiresAt:   now.Add(s.ttl),
+		}
+		result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&claim)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			return nil, nil
+		}
+
+		var existing models.IdempotencyKey
+		err = s.db.Where("user_id = ? AND key = ?", userID, key).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue // Released between our insert and read; try to claim again
+		}
+		if err != nil {
+			return nil, err
+		}
+		if existing.RequestHash != requestHash {
+			return nil, ErrIdempotencyKeyReused
+		}
+		if existing.CompletedAt != nil {
+			body, err := s.cipher.Decrypt(userID, existing.Response)
+			if err != nil {
+				return nil, err
+			}
+			existing.Response = []byte(body)
+			return &existing, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, ErrIdempotencyKeyInProgress
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(idempotencyPoll):
+		}
+	}
+}
+
+// Complete stores the response for replay. Bodies can hold decrypted notes, so
+// they are encrypted with the user's note data key.
+func (s *IdempotencyService) Complete(userID uuid.UUID, key string, status int, contentType string, body []byte) error {
+	// After account deletion, storing would recreate the shredded data key
+	var users int64
+	if err := s.db.Model(&models.User{}).Where("id = ?", userID).Count(&users).Error; err != nil {
+		return err
+	}
+	if users == 0 {
+		return s.Release(userID, key)
+	}
+
+	sealed, err := s.cipher.Encrypt(userID, string(body))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return s.db.Model(&models.IdempotencyKey{}).
+		Where("user_id = ? AND key = ? AND completed_at IS NULL", userID, key).
+		Updates(map[string]interface{}{
+			"status_code":  status,
+			"content_type": contentType,
+			"response":     sealed,
+			"completed_at": now,
+		}).Error
+}
+
+// Release drops an unfinished claim so the request can be retried
+func (s *IdempotencyService) Release(userID uuid.UUID, key string) error {
+	return s.db.Where("user_id = ? AND key = ? AND completed_at IS NULL", userID, key).
+		Delete(&models.IdempotencyKey{}).Error
+}
+
+// RunScheduler purges expired keys every interval until ctx is done
+func (s *IdempotencyService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result := s.db.Where("expires_at < ?", time.Now()).Delete(&models.IdempotencyKey{})
+		if result.Error != nil {
+			log.Printf("Idempotency key purge failed: %v", result.Error)
+		} else if result.RowsAffected > 0 {
+			log.Printf("Purged %d expired idempotency keys", result.RowsAffected)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
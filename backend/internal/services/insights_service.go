@@ -0,0 +1,624 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidInsightRange = errors.New("invalid range: must be 30d, 90d, or 1y")
+
+// InsightRanges maps the supported range keys to their length in days
+var InsightRanges = map[string]int{
+	"30d": 30,
+	"90d": 90,
+	"1y":  365,
+}
+
+type InsightsService struct {
+	db *gorm.DB
+}
+
+func NewInsightsService(db *gorm.DB) *InsightsService {
+	return &InsightsService{db: db}
+}
+
+type periodAverageRow struct {
+	Period       time.Time
+	AvgFeel      float64
+	AvgMood      float64
+	AvgEnergy    float64
+	CheckInCount int64
+}
+
+type dayOfWeekRow struct {
+	Weekday      int
+	AvgFeel      float64
+	CheckInCount int64
+}
+
+type timeOfDayRow struct {
+	Slot         string
+	AvgFeel      float64
+	CheckInCount int64
+}
+
+type bucketRow struct {
+	Bucket int
+	Count  int64
+}
+
+type streakRow struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Days      int
+	AvgFeel   float64
+}
+
+type volatilityRow struct {
+	StdDev         float64
+	AvgDailyChange float64
+}
+
+type tagEffectRow struct {
+	TagID            uuid.UUID
+	Name             string
+	Icon             string
+	Uses             int64
+	AvgFeelWith      float64
+	AvgFeelWithout   *float64
+	AvgEnergyWith    float64
+	AvgEnergyWithout *float64
+}
+
+type healthMetricRow struct {
+	Days       int64
+	Average    *float64
+	FeelCorr   *float64
+	EnergyCorr *float64
+}
+
+type mindfulnessTypeRow struct {
+	Type          string
+	Sessions      int64
+	TotalSeconds  int64
+	AvgMoodLift   float64
+	AvgEnergyLift float64
+}
+
+type mindfulnessDayRow struct {
+	DaysWith       int64
+	AvgFeelWith    *float64
+	DaysWithout    int64
+	AvgFeelWithout *float64
+	MinutesCorr    *float64
+}
+
+type emotionRow struct {
+	EmotionID string
+	Count     int64
+	AvgFeel   float64
+	AvgEnergy float64
+}
+
+// minTagUses is the minimum number of tagged check-ins before a tag is ranked
+const minTagUses = 3
+
+// minHealthDays is the minimum number of days with a metric and a check-in before it is correlated
+const minHealthDays = 7
+
+// minMindfulnessDays is the minimum number of check-in days before session minutes are correlated
+const minMindfulnessDays = 7
+
+// GetInsights computes mood trends for a user over the given range (30d, 90d, 1y)
+func (s *InsightsService) GetInsights(userID uuid.UUID, rangeKey string) (*dto.FeelInsightsResponse, error) {
+	from, today, err := insightWindow(rangeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.GetInsightsBetween(userID, from, today)
+	if err != nil {
+		return nil, err
+	}
+	resp.Range = rangeKey
+	return resp, nil
+}
+
+// GetInsightsBetween computes mood trends for check-ins dated from..to (inclusive)
+func (s *InsightsService) GetInsightsBetween(userID uuid.UUID, from, to time.Time) (*dto.FeelInsightsResponse, error) {
+	var err error
+	resp := &dto.FeelInsightsResponse{
+		From:         from.Format("2006-01-02"),
+		To:           to.Format("2006-01-02"),
+		DayOfWeek:    []dto.DayOfWeekPattern{},
+		TimeOfDay:    []dto.TimeOfDayPattern{},
+		Distribution: []dto.ScoreBucket{},
+	}
+
+	if err := s.checksInRange(userID, from, to).Count(&resp.TotalCheckIns).Error; err != nil {
+		return nil, err
+	}
+
+	if resp.Averages.Daily, err = s.periodAverages(userID, from, to, "day"); err != nil {
+		return nil, err
+	}
+	if resp.Averages.Weekly, err = s.periodAverages(userID, from, to, "week"); err != nil {
+		return nil, err
+	}
+	if resp.Averages.Monthly, err = s.periodAverages(userID, from, to, "month"); err != nil {
+		return nil, err
+	}
+
+	// Day-of-week pattern
+	var weekdays []dayOfWeekRow
+	err = s.checksInRange(userID, from, to).
+		Select("EXTRACT(ISODOW FROM check_date)::int AS weekday, AVG(feel_score) AS avg_feel, COUNT(*) AS check_in_count").
+		Group("weekday").
+		Order("weekday").
+		Scan(&weekdays).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range weekdays {
+		resp.DayOfWeek = append(resp.DayOfWeek, dto.DayOfWeekPattern{
+			Weekday:      w.Weekday,
+			Name:         time.Weekday(w.Weekday % 7).String(),
+			AvgFeelScore: round1(w.AvgFeel),
+			CheckInCount: w.CheckInCount,
+		})
+	}
+
+	// Time-of-day pattern, based on when the check-in was submitted
+	var slots []timeOfDayRow
+	err = s.checksInRange(userID, from, to).
+		Select(`CASE
+			WHEN EXTRACT(HOUR FROM created_at) BETWEEN 5 AND 11 THEN 'morning'
+			WHEN EXTRACT(HOUR FROM created_at) BETWEEN 12 AND 16 THEN 'afternoon'
+			WHEN EXTRACT(HOUR FROM created_at) BETWEEN 17 AND 21 THEN 'evening'
+			ELSE 'night' END AS slot, AVG(feel_score) AS avg_feel, COUNT(*) AS check_in_count`).
+		Group("slot").
+		Scan(&slots).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, slot := range slots {
+		resp.TimeOfDay = append(resp.TimeOfDay, dto.TimeOfDayPattern{
+			Slot:         slot.Slot,
+			AvgFeelScore: round1(slot.AvgFeel),
+			CheckInCount: slot.CheckInCount,
+		})
+	}
+
+	// Mood/energy correlation (NULL when there is no variance or too few rows)
+	var corr *float64
+	err = s.checksInRange(userID, from, to).
+		Select("CORR(mood_score, energy_score)").
+		Scan(&corr).Error
+	if err != nil {
+		return nil, err
+	}
+	if corr != nil && !math.IsNaN(*corr) {
+		r := math.Round(*corr*100) / 100
+		resp.MoodEnergyCorrelation = &r
+	}
+
+	// Score distribution in bands of 10 (1-10, 11-20, ... 91-100)
+	var buckets []bucketRow
+	err = s.checksInRange(userID, from, to).
+		Select("LEAST(GREATEST(feel_score - 1, 0) / 10, 9) AS bucket, COUNT(*) AS count").
+		Group("bucket").
+		Scan(&buckets).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[int]int64, len(buckets))
+	for _, b := range buckets {
+		counts[b.Bucket] = b.Count
+	}
+	for i := 0; i < 10; i++ {
+		resp.Distribution = append(resp.Distribution, dto.ScoreBucket{
+			Min:   i*10 + 1,
+			Max:   (i + 1) * 10,
+			Count: counts[i],
+		})
+	}
+
+	// Best streak: consecutive "Good" days or better; worst: consecutive days below "Okay"
+	if resp.BestStreak, err = s.longestStreak(userID, from, to, 60, 100); err != nil {
+		return nil, err
+	}
+	if resp.WorstStreak, err = s.longestStreak(userID, from, to, 0, 44); err != nil {
+		return nil, err
+	}
+
+	if resp.Volatility, err = s.volatility(userID, from, to); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetTagInsights ranks the user's tags by how they shift feel and energy scores
+func (s *InsightsService) GetTagInsights(userID uuid.UUID, rangeKey string) (*dto.TagInsightsResponse, error) {
+	from, today, err := insightWindow(rangeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []tagEffectRow
+	err = s.db.Raw(`
+		WITH checks AS (
+			SELECT id, feel_score, energy_score
+			FROM feel_checks
+			WHERE user_id = ? AND check_date >= ? AND deleted_at IS NULL
+		), totals AS (
+			SELECT COUNT(*) AS n, COALESCE(SUM(feel_score), 0) AS feel_sum,
+				COALESCE(SUM(energy_score), 0) AS energy_sum
+			FROM checks
+		)
+		SELECT t.id AS tag_id, t.name, t.icon, COUNT(*) AS uses,
+			AVG(c.feel_score) AS avg_feel_with,
+			(totals.feel_sum - SUM(c.feel_score))::float / NULLIF(totals.n - COUNT(*), 0) AS avg_feel_without,
+			AVG(c.energy_score) AS avg_energy_with,
+			(totals.energy_sum - SUM(c.energy_score))::float / NULLIF(totals.n - COUNT(*), 0) AS avg_energy_without
+		FROM checks c
+		JOIN feel_check_tags fct ON fct.feel_check_id = c.id
+		JOIN tags t ON t.id = fct.tag_id AND t.deleted_at IS NULL
+		CROSS JOIN totals
+		GROUP BY t.id, t.name, t.icon, totals.n, totals.feel_sum, totals.energy_sum
+		HAVING COUNT(*) >= ?`, userID, from, minTagUses).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]dto.TagInsight, 0, len(rows))
+	for _, r := range rows {
+		insight := dto.TagInsight{
+			TagID:         r.TagID.String(),
+			Name:          r.Name,
+			Icon:          r.Icon,
+			Uses:          r.Uses,
+			AvgFeelWith:   round1(r.AvgFeelWith),
+			AvgEnergyWith: round1(r.AvgEnergyWith),
+		}
+		if r.AvgFeelWithout != nil {
+			without := round1(*r.AvgFeelWithout)
+			effect := round1(r.AvgFeelWith - *r.AvgFeelWithout)
+			insight.AvgFeelWithout, insight.FeelEffect = &without, &effect
+		}
+		if r.AvgEnergyWithout != nil {
+			without := round1(*r.AvgEnergyWithout)
+			effect := round1(r.AvgEnergyWith - *r.AvgEnergyWithout)
+			insight.AvgEnergyWithout, insight.EnergyEffect = &without, &effect
+		}
+		tags = append(tags, insight)
+	}
+
+	// Strongest positive feel effect first; tags without a baseline go last
+	sort.SliceStable(tags, func(i, j int) bool {
+		a, b := tags[i].FeelEffect, tags[j].FeelEffect
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return *a > *b
+	})
+
+	return &dto.TagInsightsResponse{
+		Range: rangeKey,
+		From:  from.Format("2006-01-02"),
+		To:    today.Format("2006-01-02"),
+		Tags:  tags,
+	}, nil
+}
+
+// GetEmotionInsights groups the user's check-ins by selected emotion and quadrant
+func (s *InsightsService) GetEmotionInsights(userID uuid.UUID, rangeKey, locale string) (*dto.EmotionInsightsResponse, error) {
+	from, today, err := insightWindow(rangeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []emotionRow
+	err = s.db.Raw(`
+		SELECT fce.emotion_id, COUNT(*) AS count,
+			AVG(fc.feel_score) AS avg_feel, AVG(fc.energy_score) AS avg_energy
+		FROM feel_check_emotions fce
+		JOIN feel_checks fc ON fc.id = fce.feel_check_id
+		WHERE fc.user_id = ? AND fc.check_date >= ? AND fc.deleted_at IS NULL
+		GROUP BY fce.emotion_id
+		ORDER BY count DESC, fce.emotion_id`, userID, from).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.EmotionInsightsResponse{
+		Range:     rangeKey,
+		From:      from.Format("2006-01-02"),
+		To:        today.Format("2006-01-02"),
+		Emotions:  make([]dto.EmotionInsight, 0, len(rows)),
+		Quadrants: []dto.QuadrantInsight{},
+	}
+
+	var total int64
+	quadrantCounts := map[string]int64{}
+	for _, r := range rows {
+		emotion, ok := models.FindEmotion(r.EmotionID)
+		if !ok {
+			continue // Retired from the catalog
+		}
+		resp.Emotions = append(resp.Emotions, dto.EmotionInsight{
+			EmotionID:      emotion.ID,
+			Label:          emotion.Label(locale),
+			Emoji:          emotion.DefaultEmoji,
+			Quadrant:       emotion.Quadrant,
+			Count:          r.Count,
+			AvgFeelScore:   round1(r.AvgFeel),
+			AvgEnergyScore: round1(r.AvgEnergy),
+		})
+		quadrantCounts[emotion.Quadrant] += r.Count
+		total += r.Count
+	}
+
+	for _, q := range []string{models.QuadrantHighPleasant, models.QuadrantHighUnpleasant, models.QuadrantLowUnpleasant, models.QuadrantLowPleasant} {
+		insight := dto.QuadrantInsight{Quadrant: q, Count: quadrantCounts[q]}
+		if total > 0 {
+			insight.Share = math.Round(float64(insight.Count)/float64(total)*100) / 100
+		}
+		resp.Quadrants = append(resp.Quadrants, insight)
+	}
+
+	return resp, nil
+}
+
+// GetHealthInsights correlates imported sleep, steps and resting heart rate with
+// feel and energy scores on the same day
+func (s *InsightsService) GetHealthInsights(userID uuid.UUID, rangeKey string) (*dto.HealthInsightsResponse, error) {
+	from, today, err := insightWindow(rangeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.HealthInsightsResponse{
+		Range:   rangeKey,
+		From:    from.Format("2006-01-02"),
+		To:      today.Format("2006-01-02"),
+		Metrics: []dto.HealthMetricInsight{},
+	}
+
+	// Column names are fixed, never user input
+	for _, metric := range []string{"sleep_hours", "steps", "resting_heart_rate"} {
+		var row healthMetricRow
+		err := s.db.Raw(`
+			SELECT COUNT(dm.`+metric+`) AS days, AVG(dm.`+metric+`) AS average,
+				CORR(dm.`+metric+`, fc.feel_score) AS feel_corr,
+				CORR(dm.`+metric+`, fc.energy_score) AS energy_corr
+			FROM feel_checks fc
+			JOIN daily_metrics dm ON dm.user_id = fc.user_id AND dm.date = fc.check_date
+			WHERE fc.user_id = ? AND fc.check_date >= ? AND fc.deleted_at IS NULL`, userID, from).
+			Scan(&row).Error
+		if err != nil {
+			return nil, err
+		}
+
+		insight := dto.HealthMetricInsight{Metric: metric, Days: row.Days}
+		if row.Average != nil {
+			avg := round1(*row.Average)
+			insight.Average = &avg
+		}
+		if row.Days >= minHealthDays {
+			insight.FeelCorrelation = roundCorrelation(row.FeelCorr)
+			insight.EnergyCorrelation = roundCorrelation(row.EnergyCorr)
+		}
+		resp.Metrics = append(resp.Metrics, insight)
+	}
+
+	return resp, nil
+}
+
+// GetMindfulnessInsights averages the mood and energy lift of completed
+// sessions per type and compares check-in days with and without a session
+func (s *InsightsService) GetMindfulnessInsights(userID uuid.UUID, rangeKey string) (*dto.MindfulnessInsightsResponse, error) {
+	from, today, err := insightWindow(rangeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var types []mindfulnessTypeRow
+	err = s.db.Model(&models.MindfulnessSession{}).
+		Select("type, COUNT(*) AS sessions, SUM(duration_seconds) AS total_seconds, "+
+			"AVG(mood_after - mood_before) AS avg_mood_lift, AVG(energy_after - energy_before) AS avg_energy_lift").
+		Where("user_id = ? AND session_date >= ? AND completed_at IS NOT NULL", userID, from).
+		Group("type").
+		Order("sessions DESC, type").
+		Scan(&types).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var days mindfulnessDayRow
+	err = s.db.Raw(`
+		WITH sessions AS (
+			SELECT session_date, SUM(duration_seconds) / 60.0 AS minutes
+			FROM mindfulness_sessions
+			WHERE user_id = ? AND session_date >= ? AND completed_at IS NOT NULL
+			GROUP BY session_date
+		)
+		SELECT COUNT(s.session_date) AS days_with,
+			AVG(fc.feel_score) FILTER (WHERE s.session_date IS NOT NULL) AS avg_feel_with,
+			COUNT(*) FILTER (WHERE s.session_date IS NULL) AS days_without,
+			AVG(fc.feel_score) FILTER (WHERE s.session_date IS NULL) AS avg_feel_without,
+			CORR(COALESCE(s.minutes, 0), fc.feel_score) AS minutes_corr
+		FROM feel_checks fc
+		LEFT JOIN sessions s ON s.session_date = fc.check_date
+		WHERE fc.user_id = ? AND fc.check_date >= ? AND fc.deleted_at IS NULL`, userID, from, userID, from).
+		Scan(&days).Error
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.MindfulnessInsightsResponse{
+		Range: rangeKey,
+		From:  from.Format("2006-01-02"),
+		To:    today.Format("2006-01-02"),
+		Types: make([]dto.MindfulnessTypeInsight, 0, len(types)),
+		Days: dto.MindfulnessDayInsight{
+			DaysWithSessions: days.DaysWith,
+			DaysWithout:      days.DaysWithout,
+		},
+	}
+	for _, t := range types {
+		resp.Types = append(resp.Types, dto.MindfulnessTypeInsight{
+			Type:          t.Type,
+			Sessions:      t.Sessions,
+			TotalMinutes:  round1(float64(t.TotalSeconds) / 60),
+			AvgMoodLift:   round1(t.AvgMoodLift),
+			AvgEnergyLift: round1(t.AvgEnergyLift),
+		})
+	}
+
+	if days.AvgFeelWith != nil {
+		avg := round1(*days.AvgFeelWith)
+		resp.Days.AvgFeelWithSessions = &avg
+	}
+	if days.AvgFeelWithout != nil {
+		avg := round1(*days.AvgFeelWithout)
+		resp.Days.AvgFeelWithout = &avg
+	}
+	if days.AvgFeelWith != nil && days.AvgFeelWithout != nil {
+		effect := round1(*days.AvgFeelWith - *days.AvgFeelWithout)
+		resp.Days.FeelEffect = &effect
+	}
+	if days.DaysWith+days.DaysWithout >= minMindfulnessDays {
+		resp.Days.MinutesCorrelation = roundCorrelation(days.MinutesCorr)
+	}
+
+	return resp, nil
+}
+
+// insightWindow resolves a range key to its first and last day (inclusive)
+func insightWindow(rangeKey string) (time.Time, time.Time, error) {
+	days, ok := InsightRanges[rangeKey]
+	if !ok {
+		return time.Time{}, time.Time{}, ErrInvalidInsightRange
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	return today.AddDate(0, 0, -(days - 1)), today, nil
+}
+
+func (s *InsightsService) checksInRange(userID uuid.UUID, from, to time.Time) *gorm.DB {
+	return s.db.Model(&models.FeelCheck{}).
+		Where("user_id = ? AND check_date BETWEEN ? AND ?", userID, from, to)
+}
+
+// periodAverages returns average scores grouped by day, week or month
+func (s *InsightsService) periodAverages(userID uuid.UUID, from, to time.Time, granularity string) ([]dto.PeriodAverage, error) {
+	var rows []periodAverageRow
+	err := s.checksInRange(userID, from, to).
+		Select("DATE_TRUNC(?, check_date) AS period, AVG(feel_score) AS avg_feel, AVG(mood_score) AS avg_mood, AVG(energy_score) AS avg_energy, COUNT(*) AS check_in_count", granularity).
+		Group("period").
+		Order("period").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.PeriodAverage, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, dto.PeriodAverage{
+			Period:         r.Period.Format("2006-01-02"),
+			AvgFeelScore:   round1(r.AvgFeel),
+			AvgMoodScore:   round1(r.AvgMood),
+			AvgEnergyScore: round1(r.AvgEnergy),
+			CheckInCount:   r.CheckInCount,
+		})
+	}
+	return result, nil
+}
+
+// longestStreak finds the longest run of consecutive check-in days whose
+// feel score falls within [minScore, maxScore] (gaps-and-islands).
+func (s *InsightsService) longestStreak(userID uuid.UUID, from, to time.Time, minScore, maxScore int) (*dto.MoodStreak, error) {
+	var rows []streakRow
+	err := s.db.Raw(`
+		WITH days AS (
+			SELECT check_date, feel_score,
+				check_date - (ROW_NUMBER() OVER (ORDER BY check_date))::int AS grp
+			FROM feel_checks
+			WHERE user_id = ? AND check_date BETWEEN ? AND ? AND deleted_at IS NULL
+				AND feel_score BETWEEN ? AND ?
+		)
+		SELECT MIN(check_date) AS start_date, MAX(check_date) AS end_date,
+			COUNT(*) AS days, AVG(feel_score) AS avg_feel
+		FROM days
+		GROUP BY grp
+		ORDER BY days DESC, end_date DESC
+		LIMIT 1`, userID, from, to, minScore, maxScore).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	return &dto.MoodStreak{
+		StartDate:    rows[0].StartDate.Format("2006-01-02"),
+		EndDate:      rows[0].EndDate.Format("2006-01-02"),
+		Days:         rows[0].Days,
+		AvgFeelScore: round1(rows[0].AvgFeel),
+	}, nil
+}
+
+// volatility measures score spread and the average swing between consecutive check-ins
+func (s *InsightsService) volatility(userID uuid.UUID, from, to time.Time) (dto.InsightVolatility, error) {
+	var row volatilityRow
+	err := s.db.Raw(`
+		SELECT COALESCE(STDDEV_POP(feel_score), 0) AS std_dev,
+			COALESCE(AVG(ABS(delta)), 0) AS avg_daily_change
+		FROM (
+			SELECT feel_score, feel_score - LAG(feel_score) OVER (ORDER BY check_date) AS delta
+			FROM feel_checks
+			WHERE user_id = ? AND check_date BETWEEN ? AND ? AND deleted_at IS NULL
+		) t`, userID, from, to).
+		Scan(&row).Error
+	if err != nil {
+		return dto.InsightVolatility{}, err
+	}
+
+	level := "low"
+	switch {
+	case row.StdDev >= 20:
+		level = "high"
+	case row.StdDev >= 10:
+		level = "moderate"
+	}
+
+	return dto.InsightVolatility{
+		StdDev:         round1(row.StdDev),
+		AvgDailyChange: round1(row.AvgDailyChange),
+		Level:          level,
+	}, nil
+}
+
+// roundCorrelation rounds r to two decimals; NULL or NaN means no variance
+func roundCorrelation(r *float64) *float64 {
+	if r == nil || math.IsNaN(*r) {
+		return nil
+	}
+	v := math.Round(*r*100) / 100
+	return &v
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
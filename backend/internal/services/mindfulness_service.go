@@ -0,0 +1,201 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Mindfulness session statuses
+const (
+	SessionInProgress = "in_progress"
+	SessionCompleted  = "completed"
+	SessionAbandoned  = "abandoned" // Never completed within MaxMindfulnessDuration
+)
+
+var (
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrSessionCompleted = errors.New("session already completed")
+	ErrSessionAbandoned = errors.New("session started too long ago to complete")
+)
+
+type MindfulnessService struct {
+	db *gorm.DB
+}
+
+func NewMindfulnessService(db *gorm.DB) *MindfulnessService {
+	return &MindfulnessService{db: db}
+}
+
+// StartSession records the mood and energy before a session
+func (s *MindfulnessService) StartSession(userID uuid.UUID, req *dto.StartMindfulnessRequest) (*dto.MindfulnessSessionResponse, error) {
+	sessionType := strings.TrimSpace(req.Type)
+	if !validMindfulnessType(sessionType) {
+		return nil, fmt.Errorf("type must be one of: %s", strings.Join(models.MindfulnessTypes, ", "))
+	}
+	if req.MoodBefore < 1 || req.MoodBefore > 100 || req.EnergyBefore < 1 || req.EnergyBefore > 100 {
+		return nil, errors.New("scores must be between 1 and 100")
+	}
+
+	now := time.Now()
+	session := models.MindfulnessSession{
+		UserID:       userID,
+		Type:         sessionType,
+		SessionDate:  now.Truncate(24 * time.Hour),
+		StartedAt:    now,
+		MoodBefore:   req.MoodBefore,
+		EnergyBefore: req.EnergyBefore,
+	}
+	if err := s.db.Create(&session).Error; err != nil {
+		return nil, err
+	}
+	return mindfulnessResponse(&session, now), nil
+}
+
+// CompleteSession records the mood and energy after a session
+func (s *MindfulnessService) CompleteSession(userID, sessionID uuid.UUID, req *dto.CompleteMindfulnessRequest) (*dto.MindfulnessSessionResponse, error) {
+	if req.MoodAfter < 1 || req.MoodAfter > 100 || req.EnergyAfter < 1 || req.EnergyAfter > 100 {
+		return nil, errors.New("scores must be between 1 and 100")
+	}
+
+	var session models.MindfulnessSession
+	err := s.db.Where("id = ? AND user_id = ?", sessionID, userID).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if session.CompletedAt != nil {
+		return nil, ErrSessionCompleted
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(session.StartedAt)
+	if elapsed > models.MaxMindfulnessDuration {
+		return nil, ErrSessionAbandoned
+	}
+
+	// Clients may pause, so the practiced time can be shorter than elapsed;
+	// a minute of slack covers clock drift
+	duration := int(elapsed.Seconds())
+	if req.DurationSeconds != 0 {
+		if req.DurationSeconds < 1 || req.DurationSeconds > duration+60 {
+			return nil, errors.New("duration_seconds must be positive and no longer than the time since the session started")
+		}
+		duration = req.DurationSeconds
+	}
+
+	// Guard against a concurrent completion
+	result := s.db.Model(&session).
+		Where("completed_at IS NULL").
+		Updates(map[string]interface{}{
+			"completed_at":     now,
+			"duration_seconds": duration,
+			"mood_after":       req.MoodAfter,
+			"energy_after":     req.EnergyAfter,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrSessionCompleted
+	}
+
+	session.CompletedAt = &now
+	session.DurationSeconds = duration
+	session.MoodAfter = &req.MoodAfter
+	session.EnergyAfter = &req.EnergyAfter
+	return mindfulnessResponse(&session, now), nil
+}
+
+// ListSessions returns the user's sessions, newest first
+func (s *MindfulnessService) ListSessions(userID uuid.UUID, req *dto.MindfulnessSessionQuery) ([]dto.MindfulnessSessionResponse, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = 20
+	}
+	if limit < 1 || limit > 100 {
+		return nil, errors.New("limit must be between 1 and 100")
+	}
+
+	query := s.db.Where("user_id = ?", userID)
+	if req.Type != "" {
+		if !validMindfulnessType(req.Type) {
+			return nil, fmt.Errorf("type must be one of: %s", strings.Join(models.MindfulnessTypes, ", "))
+		}
+		query = query.Where("type = ?", req.Type)
+	}
+	if req.From != "" {
+		from, err := time.Parse("2006-01-02", req.From)
+		if err != nil {
+			return nil, errors.New("from must be a date (YYYY-MM-DD)")
+		}
+		query = query.Where("session_date >= ?", from)
+	}
+	if req.To != "" {
+		to, err := time.Parse("2006-01-02", req.To)
+		if err != nil {
+			return nil, errors.New("to must be a date (YYYY-MM-DD)")
+		}
+		query = query.Where("session_date <= ?", to)
+	}
+
+	var sessions []models.MindfulnessSession
+	if err := query.Order("started_at DESC").Limit(limit).Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resp := make([]dto.MindfulnessSessionResponse, 0, len(sessions))
+	for i := range sessions {
+		resp = append(resp, *mindfulnessResponse(&sessions[i], now))
+	}
+	return resp, nil
+}
+
+func validMindfulnessType(sessionType string) bool {
+	for _, t := range models.MindfulnessTypes {
+		if t == sessionType {
+			return true
+		}
+	}
+	return false
+}
+
+func mindfulnessResponse(session *models.MindfulnessSession, now time.Time) *dto.MindfulnessSessionResponse {
+	resp := &dto.MindfulnessSessionResponse{
+		ID:              session.ID.String(),
+		Type:            session.Type,
+		Status:          SessionInProgress,
+		Date:            session.SessionDate.Format("2006-01-02"),
+		StartedAt:       session.StartedAt,
+		CompletedAt:     session.CompletedAt,
+		DurationSeconds: session.DurationSeconds,
+		MoodBefore:      session.MoodBefore,
+		EnergyBefore:    session.EnergyBefore,
+		MoodAfter:       session.MoodAfter,
+		EnergyAfter:     session.EnergyAfter,
+	}
+	switch {
+	case session.CompletedAt != nil:
+		resp.Status = SessionCompleted
+	case now.Sub(session.StartedAt) > models.MaxMindfulnessDuration:
+		resp.Status = SessionAbandoned
+	}
+	if session.MoodAfter != nil {
+		delta := *session.MoodAfter - session.MoodBefore
+		resp.MoodDelta = &delta
+	}
+	if session.EnergyAfter != nil {
+		delta := *session.EnergyAfter - session.EnergyBefore
+		resp.EnergyDelta = &delta
+	}
+	return resp
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Mood import formats
+const (
+	ImportFormatDaylio   = "daylio"
+	ImportFormatBearable = "bearable"
+	ImportFormatCSV      = "csv"
+)
+
+// moodEntry is one entry mapped from another app's export. Several entries can
+// fall on the same day; they are merged into one check-in.
+type moodEntry struct {
+	line   int
+	date   time.Time
+	at     time.Time // Time of day if the source has one
+	mood   *float64  // 1-100
+	energy *float64  // 1-100
+	emoji  string
+	note   string
+}
+
+// moodImportAdapter maps rows of one app's CSV export to entries. Columns are
+// keyed by normalized header name. A nil entry means the row holds nothing to import.
+type moodImportAdapter struct {
+	format string
+	detect func(cols map[string]int) bool
+	entry  func(row csvRow) (*moodEntry, error)
+}
+
+// moodImportAdapters are tried in order when the format is not given, so the
+// generic CSV, whose columns other exports also have, comes last
+var moodImportAdapters = []moodImportAdapter{
+	{format: ImportFormatDaylio, detect: hasColumns("full_date", "mood"), entry: daylioEntry},
+	{format: ImportFormatBearable, detect: hasColumns("date", "category", "rating_amount"), entry: bearableEntry},
+	{format: ImportFormatCSV, detect: hasColumns("date", "mood_score"), entry: genericEntry},
+}
+
+// daylioMoods maps Daylio's default five moods to scores and emojis
+var daylioMoods = map[string]struct {
+	score float64
+	emoji string
+}{
+	"rad":   {90, "😄"},
+	"good":  {70, "🙂"},
+	"meh":   {50, "😐"},
+	"bad":   {30, "🙁"},
+	"awful": {10, "😢"},
+}
+
+// daylioEntry maps a Daylio export row:
+// full_date,date,weekday,time,mood,activities,note_title,note
+func daylioEntry(row csvRow) (*moodEntry, error) {
+	date, err := importDate(row.get("full_date"))
+	if err != nil {
+		return nil, err
+	}
+	mood, ok := daylioMoods[strings.ToLower(row.get("mood"))]
+	if !ok {
+		return nil, fmt.Errorf("unknown Daylio mood %q", row.get("mood"))
+	}
+
+	note := row.get("note")
+	if title := row.get("note_title"); title != "" {
+		note = strings.TrimSpace(title + "\n" + note)
+	}
+	note = strings.NewReplacer("<br>", "\n", "<br/>", "\n").Replace(note)
+
+	return &moodEntry{
+		date:  date,
+		at:    importTime(date, row.get("time")),
+		mood:  &mood.score,
+		emoji: mood.emoji,
+		note:  note,
+	}, nil
+}
+
+// bearableEntry maps a Bearable export row, one factor per row:
+// date,time of day,category,rating/amount,detail,notes. Mood and energy are rated 1-5.
+func bearableEntry(row csvRow) (*moodEntry, error) {
+	date, err := importDate(row.get("date"))
+	if err != nil {
+		return nil, err
+	}
+	entry := &moodEntry{date: date, at: importTime(date, row.get("time_of_day"))}
+
+	switch strings.ToLower(row.get("category")) {
+	case "mood":
+		if entry.mood, err = fivePointScore(row.get("rating_amount")); err != nil {
+			return nil, err
+		}
+	case "energy":
+		if entry.energy, err = fivePointScore(row.get("rating_amount")); err != nil {
+			return nil, err
+		}
+	case "notes", "note":
+		entry.note = row.get("detail")
+		if entry.note == "" {
+			entry.note = row.get("notes")
+		}
+	default:
+		return nil, nil // Symptoms, medication and other factors
+	}
+	return entry, nil
+}
+
+// genericEntry maps a CSV with date, mood_score (1-100) and optional
+// energy_score, mood_emoji, note and time columns
+func genericEntry(row csvRow) (*moodEntry, error) {
+	date, err := importDate(row.get("date"))
+	if err != nil {
+		return nil, err
+	}
+	entry := &moodEntry{
+		date:  date,
+		at:    importTime(date, row.get("time")),
+		emoji: row.get("mood_emoji"),
+		note:  row.get("note"),
+	}
+	if entry.mood, err = percentScore(row.get("mood_score"), "mood_score"); err != nil {
+		return nil, err
+	}
+	if raw := row.get("energy_score"); raw != "" {
+		if entry.energy, err = percentScore(raw, "energy_score"); err != nil {
+			return nil, err
+		}
+	}
+	return entry, nil
+}
+
+// csvRow reads cells by normalized column name
+type csvRow struct {
+	cols   map[string]int
+	record []string
+}
+
+func (r csvRow) get(name string) string {
+	i, ok := r.cols[name]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+	return strings.TrimSpace(r.record[i])
+}
+
+// normalizeColumn turns "Rating/Amount" into "rating_amount"
+func normalizeColumn(name string) string {
+	name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	return strings.NewReplacer(" ", "_", "-", "_", "/", "_").Replace(name)
+}
+
+func hasColumns(names ...string) func(cols map[string]int) bool {
+	return func(cols map[string]int) bool {
+		for _, name := range names {
+			if _, ok := cols[name]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func importDate(raw string) (time.Time, error) {
+	if len(raw) >= 10 {
+		if date, err := time.Parse("2006-01-02", raw[:10]); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("date %q must be YYYY-MM-DD", raw)
+}
+
+// importTime combines a date with a "21:34" or "9:34 PM" time, or returns zero
+func importTime(date time.Time, raw string) time.Time {
+	for _, layout := range []string{"15:04", "3:04 PM", "15:04:05"} {
+		if t, err := time.Parse(layout, strings.ToUpper(raw)); err == nil {
+			return date.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+		}
+	}
+	return time.Time{}
+}
+
+// fivePointScore maps a 1-5 rating to 10, 30, 50, 70 or 90
+func fivePointScore(raw string) (*float64, error) {
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil || v < 1 || v > 5 {
+		return nil, fmt.Errorf("rating %q must be between 1 and 5", raw)
+	}
+	score := (v-1)*20 + 10
+	return &score, nil
+}
+
+func percentScore(raw, column string) (*float64, error) {
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil || v < 1 || v > 100 {
+		return nil, fmt.Errorf("%s %q must be between 1 and 100", column, raw)
+	}
+	return &v, nil
+}
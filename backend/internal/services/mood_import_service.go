@@ -0,0 +1,284 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"mime/multipart"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxImportErrors  = 20
+	maxImportPreview = 20
+	// defaultImportEnergy stands in for sources that don't track energy
+	defaultImportEnergy = 50
+)
+
+var (
+	ErrUnknownImportFormat = errors.New("unrecognized export; format must be daylio, bearable or csv")
+	ErrInvalidMoodImport   = errors.New("invalid export file")
+)
+
+type MoodImportService struct {
+	db    *gorm.DB
+	notes *NoteCipher
+	feels *FeelService
+}
+
+func NewMoodImportService(db *gorm.DB, notes *NoteCipher, feels *FeelService) *MoodImportService {
+	return &MoodImportService{db: db, notes: notes, feels: feels}
+}
+
+// moodDay merges a day's entries into one check-in
+type moodDay struct {
+	date      time.Time
+	at        time.Time
+	moodSum   float64
+	moodN     int
+	energySum float64
+	energyN   int
+	emoji     string
+	notes     []string
+}
+
+// Import maps another app's export to check-ins. Days that already have a
+// check-in are skipped. With dryRun nothing is saved. format may be empty to
+// detect it from the header row.
+func (s *MoodImportService) Import(userID uuid.UUID, file *multipart.FileHeader, format string, dryRun bool) (*dto.MoodImportResponse, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	resp := &dto.MoodImportResponse{
+		DryRun:  dryRun,
+		Errors:  []dto.MoodImportError{},
+		Preview: []dto.MoodImportPreview{},
+	}
+	days, err := s.readEntries(src, format, resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(days) == 0 {
+		return resp, nil
+	}
+
+	resp.Days = len(days)
+	resp.From = days[0].date.Format("2006-01-02")
+	resp.To = days[len(days)-1].date.Format("2006-01-02")
+
+	var existing []time.Time
+	err = s.db.Model(&models.FeelCheck{}).
+		Where("user_id = ? AND check_date BETWEEN ? AND ?", userID, days[0].date, days[len(days)-1].date).
+		Pluck("check_date", &existing).Error
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, d := range existing {
+		taken[d.Format("2006-01-02")] = true
+	}
+
+	checks := make([]models.FeelCheck, 0, len(days))
+	for _, day := range days {
+		check := day.check(userID)
+		duplicate := taken[day.date.Format("2006-01-02")]
+		if len(resp.Preview) < maxImportPreview {
+			resp.Preview = append(resp.Preview, dto.MoodImportPreview{
+				Date:        day.date.Format("2006-01-02"),
+				MoodScore:   check.MoodScore,
+				EnergyScore: check.EnergyScore,
+				FeelScore:   check.FeelScore,
+				MoodEmoji:   check.MoodEmoji,
+				Note:        check.Note,
+				Duplicate:   duplicate,
+			})
+		}
+		if duplicate {
+			resp.Duplicates++
+			continue
+		}
+		checks = append(checks, check)
+	}
+	resp.Imported = len(checks)
+	if dryRun || len(checks) == 0 {
+		return resp, nil
+	}
+
+	for i := range checks {
+		if checks[i].EncryptedNote, err = s.notes.Encrypt(userID, checks[i].Note); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.db.CreateInBatches(&checks, 200).Error; err != nil {
+		return nil, err
+	}
+
+	// Imported days fill in the past, so the streak is rebuilt from scratch.
+	// Old days don't raise wellbeing alerts.
+	if err := s.feels.RebuildStreak(userID); err != nil {
+		return nil, err
+	}
+	if err := s.feels.goals.EvaluateGoals(userID); err != nil {
+		log.Printf("Goal evaluation failed for user %s: %v", userID, err)
+	}
+
+	return resp, nil
+}
+
+// readEntries parses the export and merges entries by day, oldest first
+func (s *MoodImportService) readEntries(r io.Reader, format string, resp *dto.MoodImportResponse) ([]*moodDay, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, ErrInvalidMoodImport
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		cols[normalizeColumn(name)] = i
+	}
+
+	adapter, err := findMoodAdapter(cols, strings.ToLower(strings.TrimSpace(format)))
+	if err != nil {
+		return nil, err
+	}
+	resp.Format = adapter.format
+
+	today := time.Now().Truncate(24 * time.Hour)
+	byDate := map[string]*moodDay{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidMoodImport, err)
+		}
+		resp.Rows++
+
+		entry, err := adapter.entry(csvRow{cols: cols, record: record})
+		if err == nil && entry != nil && entry.date.After(today) {
+			err = errors.New("date is in the future")
+		}
+		if err != nil {
+			resp.Skipped++
+			if len(resp.Errors) < maxImportErrors {
+				resp.Errors = append(resp.Errors, dto.MoodImportError{Line: line, Message: err.Error()})
+			}
+			continue
+		}
+		if entry == nil {
+			continue
+		}
+
+		key := entry.date.Format("2006-01-02")
+		day, ok := byDate[key]
+		if !ok {
+			day = &moodDay{date: entry.date}
+			byDate[key] = day
+		}
+		day.add(entry)
+	}
+
+	days := make([]*moodDay, 0, len(byDate))
+	for _, day := range byDate {
+		if day.moodN > 0 { // Energy or notes alone don't make a check-in
+			days = append(days, day)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].date.Before(days[j].date) })
+	return days, nil
+}
+
+func findMoodAdapter(cols map[string]int, format string) (*moodImportAdapter, error) {
+	for i := range moodImportAdapters {
+		adapter := &moodImportAdapters[i]
+		if format != "" && adapter.format != format {
+			continue
+		}
+		if adapter.detect(cols) {
+			return adapter, nil
+		}
+		if format != "" {
+			return nil, fmt.Errorf("%w: it does not look like a %s export", ErrInvalidMoodImport, format)
+		}
+	}
+	return nil, ErrUnknownImportFormat
+}
+
+func (d *moodDay) add(e *moodEntry) {
+	if e.mood != nil {
+		d.moodSum += *e.mood
+		d.moodN++
+	}
+	if e.energy != nil {
+		d.energySum += *e.energy
+		d.energyN++
+	}
+	// The latest entry of the day picks the emoji
+	if e.emoji != "" && (d.emoji == "" || !e.at.Before(d.at)) {
+		d.emoji = e.emoji
+	}
+	if e.at.After(d.at) {
+		d.at = e.at
+	}
+	if note := strings.TrimSpace(e.note); note != "" {
+		d.notes = append(d.notes, note)
+	}
+}
+
+// check builds the check-in for the day; the note is encrypted before saving
+func (d *moodDay) check(userID uuid.UUID) models.FeelCheck {
+	energy := float64(defaultImportEnergy)
+	if d.energyN > 0 {
+		energy = d.energySum / float64(d.energyN)
+	}
+
+	note := strings.Join(d.notes, "\n")
+	if utf8.RuneCountInString(note) > maxNoteLength {
+		note = string([]rune(note)[:maxNoteLength])
+	}
+	emoji := d.emoji
+	if len(emoji) > 10 {
+		emoji = ""
+	}
+
+	createdAt := d.at
+	if createdAt.IsZero() {
+		createdAt = d.date.Add(12 * time.Hour)
+	}
+
+	check := models.FeelCheck{
+		ID:          uuid.New(),
+		UserID:      userID,
+		MoodScore:   clampImportScore(d.moodSum / float64(d.moodN)),
+		EnergyScore: clampImportScore(energy),
+		MoodEmoji:   emoji,
+		Note:        note,
+		CheckDate:   d.date,
+		CreatedAt:   createdAt,
+	}
+	check.CalculateFeelScore()
+	check.ColorHex = check.GetColorHex()
+	return check
+}
+
+func clampImportScore(v float64) int {
+	return int(math.Max(1, math.Min(100, math.Round(v))))
+}
@@ -0,0 +1,366 @@
+package services
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/config"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrMasterKeyMissing = errors.New("note master key is not configured")
+	ErrNoteKeyShredded  = errors.New("note key no longer exists")
+)
+
+// noteFormatV1 prefixes ciphertexts so the format can evolve
+const noteFormatV1 byte = 1
+
+// NoteCipher encrypts check-in notes with a per-user AES-256-GCM data key.
+// Data keys are stored wrapped by a master key; retired master keys are kept
+// only to unwrap keys until RotateMasterKey has rewrapped them.
+type NoteCipher struct {
+	db           *gorm.DB
+	masterKeys   map[string][]byte
+	currentKeyID string
+}
+
+func NewNoteCipher(db *gorm.DB, cfg *config.Config) (*NoteCipher, error) {
+	encoded := strings.TrimSpace(cfg.NoteMasterKey)
+	if encoded == "" && cfg.NoteMasterKeyFile != "" {
+		raw, err := os.ReadFile(cfg.NoteMasterKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read note master key file: %w", err)
+		}
+		encoded = strings.TrimSpace(string(raw))
+	}
+	if encoded == "" {
+		return nil, ErrMasterKeyMissing
+	}
+
+	current, err := decodeMasterKey(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &NoteCipher{
+		db:           db,
+		masterKeys:   map[string][]byte{cfg.NoteMasterKeyID: current},
+		currentKeyID: cfg.NoteMasterKeyID,
+	}
+
+	for _, pair := range strings.Split(cfg.NoteRetiredMasterKeys, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		id, encodedKey, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, errors.New("retired master keys must be id:key pairs")
+		}
+		key, err := decodeMasterKey(encodedKey)
+		if err != nil {
+			return nil, err
+		}
+		c.masterKeys[id] = key
+	}
+
+	return c, nil
+}
+
+// Encrypt encrypts a note under the user's data key, creating the key if needed.
+// Empty notes are stored as nil.
+func (c *NoteCipher) Encrypt(userID uuid.UUID, plaintext string) ([]byte, error) {
+	if plaintext == "" {
+		return nil, nil
+	}
+
+	dataKey, err := c.dataKey(userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := sealGCM(dataKey, []byte(plaintext), userID[:])
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{noteFormatV1}, sealed...), nil
+}
+
+// Decrypt opens a value sealed by Encrypt. It fails with ErrNoteKeyShredded once
+// the user's data key has been destroyed.
+func (c *NoteCipher) Decrypt(userID uuid.UUID, blob []byte) (string, error) {
+	if len(blob) == 0 {
+		return "", nil
+	}
+
+	dataKey, err := c.dataKey(userID, false)
+	if err != nil {
+		return "", err
+	}
+	return c.openNote(dataKey, blob, userID)
+}
+
+// DecryptNotes fills in Note for each check-in belonging to the user.
+// Notes of a shredded user decrypt to an empty string.
+func (c *NoteCipher) DecryptNotes(userID uuid.UUID, checks []models.FeelCheck) error {
+	var dataKey []byte
+	shredded := false
+	for i := range checks {
+		if len(checks[i].EncryptedNote) == 0 {
+			checks[i].Note = checks[i].LegacyNote
+			continue
+		}
+		if shredded {
+			continue
+		}
+
+		if dataKey == nil {
+			key, err := c.dataKey(userID, false)
+			if errors.Is(err, ErrNoteKeyShredded) {
+				shredded = true
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			dataKey = key
+		}
+
+		plaintext, err := c.openNote(dataKey, checks[i].EncryptedNote, userID)
+		if err != nil {
+			return err
+		}
+		checks[i].Note = plaintext
+	}
+	return nil
+}
+
+// DecryptGratitude fills in Items for each gratitude entry belonging to the user.
+// Entries of a shredded user decrypt to an empty list.
+func (c *NoteCipher) DecryptGratitude(userID uuid.UUID, entries []models.GratitudeEntry) error {
+	for i := range entries {
+		entries[i].Items = []string{}
+	}
+
+	var dataKey []byte
+	for i := range entries {
+		if len(entries[i].EncryptedItems) == 0 {
+			continue
+		}
+
+		if dataKey == nil {
+			key, err := c.dataKey(userID, false)
+			if errors.Is(err, ErrNoteKeyShredded) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			dataKey = key
+		}
+
+		plaintext, err := c.openNote(dataKey, entries[i].EncryptedItems, userID)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(plaintext), &entries[i].Items); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RotateMasterKey rewraps every data key that is not under the current master key.
+// Notes themselves are untouched since data keys do not change.
+func (c *NoteCipher) RotateMasterKey() (int, error) {
+	var keys []models.UserKey
+	if err := c.db.Where("master_key_id <> ?", c.currentKeyID).Find(&keys).Error; err != nil {
+		return 0, err
+	}
+
+	rotated := 0
+	for _, k := range keys {
+		dataKey, err := c.unwrap(&k)
+		if err != nil {
+			return rotated, fmt.Errorf("failed to unwrap key for user %s: %w", k.UserID, err)
+		}
+
+		wrapped, err := sealGCM(c.masterKeys[c.currentKeyID], dataKey, k.UserID[:])
+		if err != nil {
+			return rotated, err
+		}
+
+		err = c.db.Model(&models.UserKey{}).
+			Where("id = ?", k.ID).
+			Updates(map[string]interface{}{
+				"wrapped_key":   wrapped,
+				"master_key_id": c.currentKeyID,
+			}).Error
+		if err != nil {
+			return rotated, err
+		}
+		rotated++
+	}
+
+	return rotated, nil
+}
+
+// BackfillPlaintextNotes encrypts notes written before encryption and clears the plaintext column.
+// Safe to run repeatedly.
+func (c *NoteCipher) BackfillPlaintextNotes() (int, error) {
+	migrated := 0
+	var checks []models.FeelCheck
+	result := c.db.Unscoped().
+		Select("id", "user_id", "note").
+		Where("note <> '' AND encrypted_note IS NULL").
+		FindInBatches(&checks, 200, func(tx *gorm.DB, batch int) error {
+			for _, check := range checks {
+				encrypted, err := c.Encrypt(check.UserID, check.LegacyNote)
+				if err != nil {
+					return err
+				}
+
+				err = c.db.Unscoped().Model(&models.FeelCheck{}).
+					Where("id = ?", check.ID).
+					Updates(map[string]interface{}{
+						"encrypted_note": encrypted,
+						"note":           "",
+					}).Error
+				if err != nil {
+					return err
+				}
+				migrated++
+			}
+			return nil
+		})
+
+	if migrated > 0 {
+		log.Printf("Encrypted %d plaintext notes", migrated)
+	}
+	return migrated, result.Error
+}
+
+// dataKey loads and unwraps the user's data key, optionally generating one
+func (c *NoteCipher) dataKey(userID uuid.UUID, create bool) ([]byte, error) {
+	var k models.UserKey
+	err := c.db.Where("user_id = ?", userID).First(&k).Error
+	if err == nil {
+		return c.unwrap(&k)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if !create {
+		return nil, ErrNoteKeyShredded
+	}
+
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	wrapped, err := sealGCM(c.masterKeys[c.currentKeyID], dataKey, userID[:])
+	if err != nil {
+		return nil, err
+	}
+
+	k = models.UserKey{
+		ID:          uuid.New(),
+		UserID:      userID,
+		WrappedKey:  wrapped,
+		MasterKeyID: c.currentKeyID,
+	}
+
+	// A concurrent request may have created the key first; use whichever won
+	result := c.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoNothing: true,
+	}).Create(&k)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return c.dataKey(userID, false)
+	}
+
+	return dataKey, nil
+}
+
+func (c *NoteCipher) unwrap(k *models.UserKey) ([]byte, error) {
+	master, ok := c.masterKeys[k.MasterKeyID]
+	if !ok {
+		return nil, fmt.Errorf("master key %q is not configured", k.MasterKeyID)
+	}
+	return openGCM(master, k.WrappedKey, k.UserID[:])
+}
+
+func (c *NoteCipher) openNote(dataKey, blob []byte, userID uuid.UUID) (string, error) {
+	if len(blob) < 1 || blob[0] != noteFormatV1 {
+		return "", errors.New("unsupported note format")
+	}
+	plaintext, err := openGCM(dataKey, blob[1:], userID[:])
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// sealGCM encrypts with AES-GCM and returns nonce || ciphertext
+func sealGCM(key, plaintext, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func openGCM(key, sealed, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func decodeMasterKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("invalid note master key encoding: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, errors.New("note master key must be 32 bytes")
+	}
+	return key, nil
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// Notification is a message delivered to a user's devices
+type Notification struct {
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// Notifier delivers notifications, e.g. through a push provider
+type Notifier interface {
+	Notify(ctx context.Context, userID uuid.UUID, n Notification) error
+}
+
+// LogNotifier writes notifications to the log; meant for development
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(ctx context.Context, userID uuid.UUID, n Notification) error {
+	log.Printf("Notify %s: %s - %s %v", userID, n.Title, n.Body, n.Data)
+	return nil
+}
+
+// NewNotifier returns the notifier selected by name
+func NewNotifier(name string) (Notifier, error) {
+	switch name {
+	case "", "log":
+		return LogNotifier{}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", name)
+	}
+}
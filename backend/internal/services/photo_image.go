@@ -0,0 +1,241 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"net/http"
+)
+
+const (
+	photoMaxSide     = 2048       // Long edge of the stored image
+	thumbnailMaxSide = 320        // Long edge of the thumbnail
+	photoMaxPixels   = 50_000_000 // Rejects decompression bombs before decoding
+	photoJPEGQuality = 85
+)
+
+var ErrInvalidPhoto = errors.New("photo must be a JPEG or PNG image")
+
+// processedPhoto is a re-encoded upload. Re-encoding drops every metadata
+// block, including EXIF and GPS location.
+type processedPhoto struct {
+	ContentType string
+	Image       []byte
+	Thumbnail   []byte
+	Width       int
+	Height      int
+}
+
+// processPhoto validates an upload by its content, applies the EXIF
+// orientation, and produces a downscaled image and thumbnail without metadata
+func processPhoto(data []byte) (*processedPhoto, error) {
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return nil, ErrInvalidPhoto
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width == 0 || cfg.Height == 0 {
+		return nil, ErrInvalidPhoto
+	}
+	if cfg.Width*cfg.Height > photoMaxPixels {
+		return nil, errors.New("photo dimensions are too large")
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidPhoto
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, decoded.Bounds().Dx(), decoded.Bounds().Dy()))
+	draw.Draw(img, img.Bounds(), decoded, decoded.Bounds().Min, draw.Src)
+	if contentType == "image/jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	full := downscale(img, photoMaxSide)
+	thumb := downscale(full, thumbnailMaxSide)
+
+	photo := &processedPhoto{ContentType: contentType, Width: full.Bounds().Dx(), Height: full.Bounds().Dy()}
+	if photo.Image, err = encodePhoto(full, contentType); err != nil {
+		return nil, err
+	}
+	if photo.Thumbnail, err = encodePhoto(thumb, contentType); err != nil {
+		return nil, err
+	}
+	return photo, nil
+}
+
+func encodePhoto(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if contentType == "image/png" {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: photoJPEGQuality})
+	}
+	return buf.Bytes(), err
+}
+
+// downscale shrinks img so its long edge is at most maxSide, averaging the
+// source pixels behind each output pixel
+func downscale(img *image.RGBA, maxSide int) *image.RGBA {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	if w <= maxSide && h <= maxSide {
+		return img
+	}
+
+	scale := float64(maxSide) / math.Max(float64(w), float64(h))
+	dw := int(math.Max(1, math.Round(float64(w)*scale)))
+	dh := int(math.Max(1, math.Round(float64(h)*scale)))
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for dy := 0; dy < dh; dy++ {
+		y0, y1 := dy*h/dh, (dy+1)*h/dh
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for dx := 0; dx < dw; dx++ {
+			x0, x1 := dx*w/dw, (dx+1)*w/dw
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var sum [4]int
+			for y := y0; y < y1; y++ {
+				row := img.Pix[y*img.Stride+x0*4 : y*img.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+			n := (x1 - x0) * (y1 - y0)
+			o := dy*dst.Stride + dx*4
+			for c := 0; c < 4; c++ {
+				dst.Pix[o+c] = uint8((sum[c] + n/2) / n)
+			}
+		}
+	}
+	return dst
+}
+
+// applyOrientation turns the pixels the way EXIF orientation (1-8) says the
+// image should be displayed, since the tag itself is stripped
+func applyOrientation(img *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for dy := 0; dy < dh; dy++ {
+		for dx := 0; dx < dw; dx++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // Mirrored
+				sx, sy = w-1-dx, dy
+			case 3: // Upside down
+				sx, sy = w-1-dx, h-1-dy
+			case 4: // Mirrored upside down
+				sx, sy = dx, h-1-dy
+			case 5: // Transposed
+				sx, sy = dy, dx
+			case 6: // Needs a clockwise turn
+				sx, sy = dy, h-1-dx
+			case 7: // Transversed
+				sx, sy = w-1-dy, h-1-dx
+			case 8: // Needs a counterclockwise turn
+				sx, sy = w-1-dy, dx
+			}
+			copy(dst.Pix[dy*dst.Stride+dx*4:dy*dst.Stride+dx*4+4], img.Pix[sy*img.Stride+sx*4:sy*img.Stride+sx*4+4])
+		}
+	}
+	return dst
+}
+
+// jpegOrientation reads the EXIF orientation tag from a JPEG, or returns 1
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF: // Fill byte
+			i++
+			continue
+		case marker == 0xDA || marker == 0xD9: // Image data starts; no more metadata
+			return 1
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7): // No length
+			i += 2
+			continue
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// tiffOrientation finds tag 0x0112 in the first IFD of a TIFF header
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for k := 0; k < entries; k++ {
+		e := ifd + 2 + k*12
+		if e+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[e:]) == 0x0112 {
+			if order.Uint16(tiff[e+2:]) != 3 { // SHORT
+				return 1
+			}
+			if v := int(order.Uint16(tiff[e+8:])); v >= 1 && v <= 8 {
+				return v
+			}
+			return 1
+		}
+	}
+	return 1
+}
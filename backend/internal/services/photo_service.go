@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/config"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrFeelCheckNotFound = errors.New("check-in not found")
+	ErrPhotoNotFound     = errors.New("photo not found")
+	ErrPhotoStorage      = errors.New("failed to store photo")
+)
+
+type PhotoService struct {
+	db        *gorm.DB
+	storage   ObjectStorage
+	maxPhotos int
+	maxBytes  int64
+	urlTTL    time.Duration
+}
+
+func NewPhotoService(db *gorm.DB, storage ObjectStorage, cfg *config.Config) *PhotoService {
+	return &PhotoService{
+		db:        db,
+		storage:   storage,
+		maxPhotos: cfg.MaxPhotosPerCheck,
+		maxBytes:  int64(cfg.PhotoMaxMB) * 1024 * 1024,
+		urlTTL:    cfg.MediaURLTTL,
+	}
+}
+
+// AddPhoto attaches an uploaded image to one of the user's check-ins. The
+// image is re-encoded without metadata and stored with a thumbnail.
+func (s *PhotoService) AddPhoto(userID, checkID uuid.UUID, file *multipart.FileHeader) (*dto.PhotoResponse, error) {
+	var check models.FeelCheck
+	if err := s.db.Select("id").Where("id = ? AND user_id = ?", checkID, userID).First(&check).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFeelCheckNotFound
+		}
+		return nil, err
+	}
+	// Cheap check before decoding; the insert below enforces it
+	if err := s.checkPhotoCount(s.db, checkID); err != nil {
+		return nil, err
+	}
+
+	if file.Size > s.maxBytes {
+		return nil, fmt.Errorf("photo must be at most %d MB", s.maxBytes/1024/1024)
+	}
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(io.LimitReader(src, s.maxBytes+1))
+	src.Close()
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxBytes {
+		return nil, fmt.Errorf("photo must be at most %d MB", s.maxBytes/1024/1024)
+	}
+
+	processed, err := processPhoto(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := ".jpg"
+	if processed.ContentType == "image/png" {
+		ext = ".png"
+	}
+	photo := models.FeelCheckPhoto{
+		ID:          uuid.New(),
+		FeelCheckID: checkID,
+		UserID:      userID,
+		ContentType: processed.ContentType,
+		Width:       processed.Width,
+		Height:      processed.Height,
+		Size:        len(processed.Image),
+	}
+	photo.Key = fmt.Sprintf("photos/%s/%s%s", userID, photo.ID, ext)
+	photo.ThumbKey = fmt.Sprintf("photos/%s/%s_thumb%s", userID, photo.ID, ext)
+
+	ctx := context.Background()
+	if err := s.storage.Put(ctx, photo.Key, processed.Image, processed.ContentType); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPhotoStorage, err)
+	}
+	if err := s.storage.Put(ctx, photo.ThumbKey, processed.Thumbnail, processed.ContentType); err != nil {
+		s.storage.Delete(ctx, photo.Key)
+		return nil, fmt.Errorf("%w: %v", ErrPhotoStorage, err)
+	}
+
+	// Lock the check-in so concurrent uploads can't pass the limit together
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var locked models.FeelCheck
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+			Where("id = ? AND user_id = ?", checkID, userID).First(&locked).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrFeelCheckNotFound
+			}
+			return err
+		}
+		if err := s.checkPhotoCount(tx, checkID); err != nil {
+			return err
+		}
+		return tx.Create(&photo).Error
+	})
+	if err != nil {
+		s.deleteObjects(ctx, []models.FeelCheckPhoto{photo})
+		return nil, err
+	}
+
+	return s.photoResponse(&photo)
+}
+
+// ListPhotos returns the photos on one of the user's check-ins with fresh URLs
+func (s *PhotoService) ListPhotos(userID, checkID uuid.UUID) ([]dto.PhotoResponse, error) {
+	var count int64
+	if err := s.db.Model(&models.FeelCheck{}).Where("id = ? AND user_id = ?", checkID, userID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, ErrFeelCheckNotFound
+	}
+
+	var photos []models.FeelCheckPhoto
+	if err := s.db.Where("feel_check_id = ?", checkID).Order("created_at ASC").Find(&photos).Error; err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.PhotoResponse, 0, len(photos))
+	for i := range photos {
+		p, err := s.photoResponse(&photos[i])
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, *p)
+	}
+	return resp, nil
+}
+
+// DeletePhoto removes a photo and its files
+func (s *PhotoService) DeletePhoto(userID, checkID, photoID uuid.UUID) error {
+	var photo models.FeelCheckPhoto
+	err := s.db.Where("id = ? AND feel_check_id = ? AND user_id = ?", photoID, checkID, userID).First(&photo).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrPhotoNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return s.deletePhotos([]models.FeelCheckPhoto{photo})
+}
+
+// DeleteCheckPhotos removes every photo on a check-in, e.g. when it is deleted
+func (s *PhotoService) DeleteCheckPhotos(checkID uuid.UUID) error {
+	var photos []models.FeelCheckPhoto
+	if err := s.db.Where("feel_check_id = ?", checkID).Find(&photos).Error; err != nil {
+		return err
+	}
+	return s.deletePhotos(photos)
+}
+
+// DeleteUserPhotos removes every photo the user uploaded, for account deletion
+func (s *PhotoService) DeleteUserPhotos(userID uuid.UUID) error {
+	var photos []models.FeelCheckPhoto
+	if err := s.db.Where("user_id = ?", userID).Find(&photos).Error; err != nil {
+		return err
+	}
+	return s.deletePhotos(photos)
+}
+
+// deletePhotos removes the files first, so a storage failure leaves the rows
+// in place and the deletion can be retried
+func (s *PhotoService) deletePhotos(photos []models.FeelCheckPhoto) error {
+	if len(photos) == 0 {
+		return nil
+	}
+	if err := s.deleteObjects(context.Background(), photos); err != nil {
+		return fmt.Errorf("failed to delete photo files: %w", err)
+	}
+
+	ids := make([]uuid.UUID, len(photos))
+	for i := range photos {
+		ids[i] = photos[i].ID
+	}
+	return s.db.Where("id IN ?", ids).Delete(&models.FeelCheckPhoto{}).Error
+}
+
+func (s *PhotoService) deleteObjects(ctx context.Context, photos []models.FeelCheckPhoto) error {
+	var firstErr error
+	for _, p := range photos {
+		for _, key := range []string{p.Key, p.ThumbKey} {
+			if err := s.storage.Delete(ctx, key); err != nil && !errors.Is(err, ErrObjectNotFound) && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+func (s *PhotoService) checkPhotoCount(db *gorm.DB, checkID uuid.UUID) error {
+	var count int64
+	if err := db.Model(&models.FeelCheckPhoto{}).Where("feel_check_id = ?", checkID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count >= int64(s.maxPhotos) {
+		return fmt.Errorf("a check-in can have at most %d photos", s.maxPhotos)
+	}
+	return nil
+}
+
+func (s *PhotoService) photoResponse(photo *models.FeelCheckPhoto) (*dto.PhotoResponse, error) {
+	url, err := s.storage.SignedURL(photo.Key, s.urlTTL)
+	if err != nil {
+		return nil, err
+	}
+	thumbURL, err := s.storage.SignedURL(photo.ThumbKey, s.urlTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.PhotoResponse{
+		ID:           photo.ID.String(),
+		URL:          url,
+		ThumbnailURL: thumbURL,
+		ContentType:  photo.ContentType,
+		Width:        photo.Width,
+		Height:       photo.Height,
+		Size:         photo.Size,
+		CreatedAt:    photo.CreatedAt,
+		ExpiresAt:    time.Now().Add(s.urlTTL).Truncate(time.Second),
+	}, nil
+}
@@ -0,0 +1,340 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// promptRepeatDays is how long a prompt is held back after it was shown,
+// unless every prompt in the locale was shown within that time
+const promptRepeatDays = 30
+
+var (
+	ErrPromptNotFound = errors.New("prompt not found")
+	ErrNoPrompts      = errors.New("no reflection prompts available")
+	ErrInvalidPrompt  = errors.New("invalid prompt_id")
+)
+
+type PromptService struct {
+	db *gorm.DB
+}
+
+func NewPromptService(db *gorm.DB) *PromptService {
+	return &PromptService{db: db}
+}
+
+// GetDailyPrompt returns the user's prompt for today, choosing one on the first
+// request. A prompt chosen before today's check-in is re-chosen once the score
+// is known, unless it was already answered.
+func (s *PromptService) GetDailyPrompt(userID uuid.UUID, locale string) (*dto.DailyPromptResponse, error) {
+	today := time.Now().Truncate(24 * time.Hour)
+
+	var check models.FeelCheck
+	hasCheck := true
+	err := s.db.Select("feel_score", "prompt_id").
+		Where("user_id = ? AND check_date = ?", userID, today).
+		First(&check).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		hasCheck = false
+	} else if err != nil {
+		return nil, err
+	}
+
+	var daily models.DailyPrompt
+	err = s.db.Where("user_id = ? AND prompt_date = ?", userID, today).First(&daily).Error
+	found := err == nil
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	answered := hasCheck && check.PromptID != nil
+	if answered {
+		daily.PromptID = *check.PromptID
+	} else if !found || (hasCheck && !daily.ScoreBased) {
+		var score *int
+		if hasCheck {
+			score = &check.FeelScore
+		}
+		prompt, err := s.choosePrompt(userID, locale, today, score)
+		if err != nil {
+			return nil, err
+		}
+
+		daily = models.DailyPrompt{UserID: userID, PromptDate: today, PromptID: prompt.ID, ScoreBased: hasCheck}
+		err = s.db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "prompt_date"}},
+			DoUpdates: clause.AssignmentColumns([]string{"prompt_id", "score_based"}),
+		}).Create(&daily).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var prompt models.ReflectionPrompt
+	if err := s.db.Unscoped().First(&prompt, "id = ?", daily.PromptID).Error; err != nil {
+		return nil, err
+	}
+
+	return &dto.DailyPromptResponse{
+		ID:       prompt.ID.String(),
+		Text:     prompt.Text,
+		Category: prompt.Category,
+		Locale:   prompt.Locale,
+		Date:     today.Format("2006-01-02"),
+		Answered: answered,
+	}, nil
+}
+
+type promptCandidate struct {
+	prompt       *models.ReflectionPrompt
+	recent       bool // Shown within promptRepeatDays
+	fits         bool // Today's score is within the prompt's range
+	sameCategory bool // Same category as yesterday's prompt
+	lastShown    time.Time
+	tiebreak     uint32
+}
+
+// choosePrompt ranks the locale's active prompts: not shown recently first, then
+// prompts that fit the score, then a different category from yesterday, then the
+// least recently shown. A per-user, per-day hash breaks ties so users don't all
+// get the same prompt.
+func (s *PromptService) choosePrompt(userID uuid.UUID, locale string, today time.Time, score *int) (*models.ReflectionPrompt, error) {
+	prompts, err := s.promptsForLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []struct {
+		PromptID  uuid.UUID
+		LastShown time.Time
+	}
+	err = s.db.Model(&models.DailyPrompt{}).
+		Select("prompt_id, MAX(prompt_date) AS last_shown").
+		Where("user_id = ? AND prompt_date < ?", userID, today).
+		Group("prompt_id").
+		Scan(&history).Error
+	if err != nil {
+		return nil, err
+	}
+	lastShown := make(map[uuid.UUID]time.Time, len(history))
+	for _, h := range history {
+		lastShown[h.PromptID] = h.LastShown
+	}
+
+	var yesterday []string
+	err = s.db.Model(&models.DailyPrompt{}).
+		Joins("JOIN reflection_prompts ON reflection_prompts.id = daily_prompts.prompt_id").
+		Where("daily_prompts.user_id = ? AND daily_prompts.prompt_date = ?", userID, today.AddDate(0, 0, -1)).
+		Pluck("reflection_prompts.category", &yesterday).Error
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := today.AddDate(0, 0, -promptRepeatDays)
+	candidates := make([]promptCandidate, 0, len(prompts))
+	for i := range prompts {
+		p := &prompts[i]
+		h := fnv.New32a()
+		h.Write([]byte(userID.String() + today.Format("2006-01-02") + p.ID.String()))
+		shown := lastShown[p.ID]
+		candidates = append(candidates, promptCandidate{
+			prompt:       p,
+			recent:       !shown.IsZero() && !shown.Before(cutoff),
+			fits:         score != nil && *score >= p.MinScore && *score <= p.MaxScore,
+			sameCategory: len(yesterday) > 0 && p.Category == yesterday[0],
+			lastShown:    shown,
+			tiebreak:     h.Sum32(),
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.recent != b.recent {
+			return !a.recent
+		}
+		if a.fits != b.fits {
+			return a.fits
+		}
+		if a.sameCategory != b.sameCategory {
+			return !a.sameCategory
+		}
+		if !a.lastShown.Equal(b.lastShown) {
+			return a.lastShown.Before(b.lastShown)
+		}
+		return a.tiebreak < b.tiebreak
+	})
+	return candidates[0].prompt, nil
+}
+
+// promptsForLocale returns active prompts for the locale, falling back to its
+// language and then to English
+func (s *PromptService) promptsForLocale(locale string) ([]models.ReflectionPrompt, error) {
+	locale = normalizeLocale(locale)
+	candidates := []string{locale}
+	if lang, _, ok := strings.Cut(locale, "-"); ok {
+		candidates = append(candidates, lang)
+	}
+	candidates = append(candidates, "en")
+
+	for _, l := range candidates {
+		var prompts []models.ReflectionPrompt
+		if err := s.db.Where("locale = ? AND active = ?", l, true).Find(&prompts).Error; err != nil {
+			return nil, err
+		}
+		if len(prompts) > 0 {
+			return prompts, nil
+		}
+	}
+	return nil, ErrNoPrompts
+}
+
+// ListPrompts returns the prompt library for admins, optionally filtered
+func (s *PromptService) ListPrompts(query *dto.PromptListQuery) ([]models.ReflectionPrompt, error) {
+	db := s.db.Order("locale, category, created_at")
+	if query.Locale != "" {
+		db = db.Where("locale = ?", normalizeLocale(query.Locale))
+	}
+	if query.Category != "" {
+		db = db.Where("category = ?", query.Category)
+	}
+
+	var prompts []models.ReflectionPrompt
+	if err := db.Find(&prompts).Error; err != nil {
+		return nil, err
+	}
+	return prompts, nil
+}
+
+// CreatePrompt adds a prompt to the library
+func (s *PromptService) CreatePrompt(req *dto.CreatePromptRequest) (*models.ReflectionPrompt, error) {
+	prompt := &models.ReflectionPrompt{
+		ID:       uuid.New(),
+		Text:     strings.TrimSpace(req.Text),
+		Category: req.Category,
+		Locale:   req.Locale,
+		MinScore: req.MinScore,
+		MaxScore: req.MaxScore,
+		Active:   req.Active == nil || *req.Active,
+	}
+	if prompt.Locale == "" {
+		prompt.Locale = "en"
+	}
+	if prompt.MinScore == 0 {
+		prompt.MinScore = 1
+	}
+	if prompt.MaxScore == 0 {
+		prompt.MaxScore = 100
+	}
+	if err := validatePrompt(prompt); err != nil {
+		return nil, err
+	}
+
+	// Select all columns so an inactive prompt isn't saved with the column default
+	if err := s.db.Select("*").Create(prompt).Error; err != nil {
+		return nil, err
+	}
+	return prompt, nil
+}
+
+// UpdatePrompt changes the given fields of a prompt
+func (s *PromptService) UpdatePrompt(id uuid.UUID, req *dto.UpdatePromptRequest) (*models.ReflectionPrompt, error) {
+	var prompt models.ReflectionPrompt
+	if err := s.db.First(&prompt, "id = ?", id).Error; err != nil {
+		return nil, ErrPromptNotFound
+	}
+
+	if req.Text != nil {
+		prompt.Text = strings.TrimSpace(*req.Text)
+	}
+	if req.Category != nil {
+		prompt.Category = *req.Category
+	}
+	if req.Locale != nil {
+		prompt.Locale = *req.Locale
+	}
+	if req.MinScore != nil {
+		prompt.MinScore = *req.MinScore
+	}
+	if req.MaxScore != nil {
+		prompt.MaxScore = *req.MaxScore
+	}
+	if req.Active != nil {
+		prompt.Active = *req.Active
+	}
+	if err := validatePrompt(&prompt); err != nil {
+		return nil, err
+	}
+
+	err := s.db.Model(&prompt).Select("text", "category", "locale", "min_score", "max_score", "active").Updates(&prompt).Error
+	if err != nil {
+		return nil, err
+	}
+	return &prompt, nil
+}
+
+// DeletePrompt removes a prompt from rotation. Check-ins that answered it keep
+// the reference.
+func (s *PromptService) DeletePrompt(id uuid.UUID) error {
+	result := s.db.Delete(&models.ReflectionPrompt{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPromptNotFound
+	}
+	return nil
+}
+
+func validatePrompt(prompt *models.ReflectionPrompt) error {
+	if prompt.Text == "" || utf8.RuneCountInString(prompt.Text) > 280 {
+		return errors.New("text is required and must be at most 280 characters")
+	}
+	valid := false
+	for _, c := range models.PromptCategories {
+		if prompt.Category == c {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("category must be one of: %s", strings.Join(models.PromptCategories, ", "))
+	}
+	prompt.Locale = normalizeLocale(prompt.Locale)
+	if len(prompt.Locale) > 10 {
+		return errors.New("locale must be at most 10 characters")
+	}
+	if prompt.MinScore < 1 || prompt.MaxScore > 100 || prompt.MinScore > prompt.MaxScore {
+		return errors.New("min_score and max_score must be between 1 and 100, with min_score <= max_score")
+	}
+	return nil
+}
+
+// findPrompt resolves the prompt a check-in answers; empty means none
+func findPrompt(db *gorm.DB, rawID string) (*uuid.UUID, error) {
+	if rawID == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, ErrInvalidPrompt
+	}
+	var count int64
+	if err := db.Model(&models.ReflectionPrompt{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, ErrInvalidPrompt
+	}
+	return &id, nil
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+)
+
+// QuestionnaireCatalog maps questionnaire IDs to their definitions
+type QuestionnaireCatalog map[string]*dto.QuestionnaireDefinition
+
+var frequencyOptions = []dto.QuestionnaireOption{
+	{Value: 0, Label: "Not at all"},
+	{Value: 1, Label: "Several days"},
+	{Value: 2, Label: "More than half the days"},
+	{Value: 3, Label: "Nearly every day"},
+}
+
+// defaultQuestionnaires are always available; QUESTIONNAIRES_FILE can add
+// instruments or replace these by ID
+var defaultQuestionnaires = []dto.QuestionnaireDefinition{
+	{
+		ID:           "phq9",
+		Version:      1,
+		Name:         "PHQ-9",
+		Description:  "Patient Health Questionnaire for depression symptoms",
+		Instructions: "Over the last 2 weeks, how often have you been bothered by any of the following problems?",
+		LookbackDays: 14,
+		IntervalDays: 14,
+		Options:      frequencyOptions,
+		Items: []dto.QuestionnaireItem{
+			{ID: "1", Text: "Little interest or pleasure in doing things"},
+			{ID: "2", Text: "Feeling down, depressed, or hopeless"},
+			{ID: "3", Text: "Trouble falling or staying asleep, or sleeping too much"},
+			{ID: "4", Text: "Feeling tired or having little energy"},
+			{ID: "5", Text: "Poor appetite or overeating"},
+			{ID: "6", Text: "Feeling bad about yourself, or that you are a failure or have let yourself or your family down"},
+			{ID: "7", Text: "Trouble concentrating on things, such as reading the newspaper or watching television"},
+			{ID: "8", Text: "Moving or speaking so slowly that other people could have noticed, or the opposite: being so fidgety or restless that you have been moving around a lot more than usual"},
+			{ID: "9", Text: "Thoughts that you would be better off dead, or of hurting yourself in some way", Safety: true},
+		},
+		Bands: []dto.SeverityBand{
+			{Min: 0, Max: 4, Severity: "minimal", Label: "Minimal"},
+			{Min: 5, Max: 9, Severity: "mild", Label: "Mild"},
+			{Min: 10, Max: 14, Severity: "moderate", Label: "Moderate"},
+			{Min: 15, Max: 19, Severity: "moderately_severe", Label: "Moderately severe"},
+			{Min: 20, Max: 27, Severity: "severe", Label: "Severe"},
+		},
+	},
+	{
+		ID:           "gad7",
+		Version:      1,
+		Name:         "GAD-7",
+		Description:  "Generalized Anxiety Disorder scale",
+		Instructions: "Over the last 2 weeks, how often have you been bothered by the following problems?",
+		LookbackDays: 14,
+		IntervalDays: 14,
+		Options:      frequencyOptions,
+		Items: []dto.QuestionnaireItem{
+			{ID: "1", Text: "Feeling nervous, anxious, or on edge"},
+			{ID: "2", Text: "Not being able to stop or control worrying"},
+			{ID: "3", Text: "Worrying too much about different things"},
+			{ID: "4", Text: "Trouble relaxing"},
+			{ID: "5", Text: "Being so restless that it is hard to sit still"},
+			{ID: "6", Text: "Becoming easily annoyed or irritable"},
+			{ID: "7", Text: "Feeling afraid, as if something awful might happen"},
+		},
+		Bands: []dto.SeverityBand{
+			{Min: 0, Max: 4, Severity: "minimal", Label: "Minimal"},
+			{Min: 5, Max: 9, Severity: "mild", Label: "Mild"},
+			{Min: 10, Max: 14, Severity: "moderate", Label: "Moderate"},
+			{Min: 15, Max: 21, Severity: "severe", Label: "Severe"},
+		},
+	},
+	{
+		ID:           "who5",
+		Version:      1,
+		Name:         "WHO-5",
+		Description:  "WHO-5 Well-Being Index",
+		Instructions: "Please indicate for each statement which is closest to how you have been feeling over the last two weeks.",
+		LookbackDays: 14,
+		IntervalDays: 14,
+		Options: []dto.QuestionnaireOption{
+			{Value: 5, Label: "All of the time"},
+			{Value: 4, Label: "Most of the time"},
+			{Value: 3, Label: "More than half of the time"},
+			{Value: 2, Label: "Less than half of the time"},
+			{Value: 1, Label: "Some of the time"},
+			{Value: 0, Label: "At no time"},
+		},
+		Items: []dto.QuestionnaireItem{
+			{ID: "1", Text: "I have felt cheerful and in good spirits"},
+			{ID: "2", Text: "I have felt calm and relaxed"},
+			{ID: "3", Text: "I have felt active and vigorous"},
+			{ID: "4", Text: "I woke up feeling fresh and rested"},
+			{ID: "5", Text: "My daily life has been filled with things that interest me"},
+		},
+		Multiplier:     4, // Raw 0-25 as a percentage
+		HigherIsBetter: true,
+		Bands: []dto.SeverityBand{
+			{Min: 0, Max: 28, Severity: "low", Label: "Very low well-being"},
+			{Min: 29, Max: 50, Severity: "poor", Label: "Poor well-being"},
+			{Min: 51, Max: 100, Severity: "good", Label: "Good well-being"},
+		},
+	},
+}
+
+// loadQuestionnaires returns the default catalog merged with definitions from a JSON file
+func loadQuestionnaires(path string) (QuestionnaireCatalog, error) {
+	defs := defaultQuestionnaires
+	if path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read questionnaires: %w", err)
+		}
+		var extra []dto.QuestionnaireDefinition
+		if err := json.Unmarshal(raw, &extra); err != nil {
+			return nil, fmt.Errorf("invalid questionnaires file: %w", err)
+		}
+		defs = append(append([]dto.QuestionnaireDefinition{}, defs...), extra...)
+	}
+
+	catalog := make(QuestionnaireCatalog, len(defs))
+	for i := range defs {
+		def := defs[i]
+		def.ID = strings.ToLower(strings.TrimSpace(def.ID))
+		if def.Multiplier == 0 {
+			def.Multiplier = 1
+		}
+		if def.Version == 0 {
+			def.Version = 1
+		}
+		if err := validateQuestionnaire(&def); err != nil {
+			return nil, fmt.Errorf("questionnaire %q: %w", def.ID, err)
+		}
+		catalog[def.ID] = &def // Later definitions replace earlier ones
+	}
+	return catalog, nil
+}
+
+// validateQuestionnaire checks that every possible score falls in exactly one band
+func validateQuestionnaire(def *dto.QuestionnaireDefinition) error {
+	if def.ID == "" || len(def.ID) > 30 {
+		return fmt.Errorf("id must be 1-30 characters")
+	}
+	if def.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if def.LookbackDays < 1 || def.IntervalDays < 1 {
+		return fmt.Errorf("lookback_days and interval_days must be positive")
+	}
+	if len(def.Options) == 0 || len(def.Items) == 0 {
+		return fmt.Errorf("options and items are required")
+	}
+	if def.Multiplier < 1 {
+		return fmt.Errorf("multiplier must be positive")
+	}
+
+	seen := make(map[string]bool, len(def.Items))
+	for _, item := range def.Items {
+		if item.ID == "" || seen[item.ID] {
+			return fmt.Errorf("item ids must be unique and non-empty")
+		}
+		seen[item.ID] = true
+	}
+
+	values := make(map[int]bool, len(def.Options))
+	for _, o := range def.Options {
+		if o.Value < 0 || values[o.Value] {
+			return fmt.Errorf("option values must be unique and not negative")
+		}
+		values[o.Value] = true
+	}
+
+	bands := append([]dto.SeverityBand{}, def.Bands...)
+	sort.Slice(bands, func(i, j int) bool { return bands[i].Min < bands[j].Min })
+	for i, b := range bands {
+		if b.Severity == "" || b.Max < b.Min {
+			return fmt.Errorf("bands need a severity and min <= max")
+		}
+		if i > 0 && b.Min <= bands[i-1].Max {
+			return fmt.Errorf("bands overlap")
+		}
+	}
+	def.Bands = bands
+
+	lo, hi := questionnaireScoreRange(def)
+	for score := lo; score <= hi; score += def.Multiplier {
+		if questionnaireBand(def, score) == nil {
+			return fmt.Errorf("no band covers score %d", score)
+		}
+	}
+	return nil
+}
+
+// questionnaireScoreRange returns the lowest and highest possible scores
+func questionnaireScoreRange(def *dto.QuestionnaireDefinition) (int, int) {
+	lo, hi := def.Options[0].Value, def.Options[0].Value
+	for _, o := range def.Options {
+		if o.Value < lo {
+			lo = o.Value
+		}
+		if o.Value > hi {
+			hi = o.Value
+		}
+	}
+	n := len(def.Items) * def.Multiplier
+	return lo * n, hi * n
+}
+
+func questionnaireBand(def *dto.QuestionnaireDefinition, score int) *dto.SeverityBand {
+	for i := range def.Bands {
+		if score >= def.Bands[i].Min && score <= def.Bands[i].Max {
+			return &def.Bands[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,437 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	minQuestionnaireInterval = 7
+	maxQuestionnaireInterval = 180
+	maxQuestionnaireResults  = 100
+	// Prompts only go out during the user's local daytime
+	questionnairePromptFrom = 9
+	questionnairePromptTo   = 21
+)
+
+var ErrQuestionnaireNotFound = errors.New("questionnaire not found")
+
+type QuestionnaireService struct {
+	db        *gorm.DB
+	catalog   QuestionnaireCatalog
+	wellbeing *WellbeingService
+	notifier  Notifier
+}
+
+func NewQuestionnaireService(db *gorm.DB, definitionsFile string, wellbeing *WellbeingService, notifier Notifier) (*QuestionnaireService, error) {
+	catalog, err := loadQuestionnaires(definitionsFile)
+	if err != nil {
+		return nil, err
+	}
+	return &QuestionnaireService{db: db, catalog: catalog, wellbeing: wellbeing, notifier: notifier}, nil
+}
+
+// GetDefinition returns a questionnaire's items, options and bands
+func (s *QuestionnaireService) GetDefinition(id string) (*dto.QuestionnaireDefinition, error) {
+	def, ok := s.catalog[id]
+	if !ok {
+		return nil, ErrQuestionnaireNotFound
+	}
+	return def, nil
+}
+
+// ListQuestionnaires returns every questionnaire with the user's schedule and latest result
+func (s *QuestionnaireService) ListQuestionnaires(userID uuid.UUID) ([]dto.QuestionnaireSummary, error) {
+	var schedules []models.QuestionnaireSchedule
+	if err := s.db.Where("user_id = ?", userID).Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*models.QuestionnaireSchedule, len(schedules))
+	for i := range schedules {
+		byID[schedules[i].QuestionnaireID] = &schedules[i]
+	}
+
+	var latest []models.QuestionnaireResult
+	err := s.db.Raw(`
+		SELECT DISTINCT ON (questionnaire_id) *
+		FROM questionnaire_results
+		WHERE user_id = ?
+		ORDER BY questionnaire_id, completed_at DESC`, userID).
+		Scan(&latest).Error
+	if err != nil {
+		return nil, err
+	}
+	lastByID := make(map[string]*models.QuestionnaireResult, len(latest))
+	for i := range latest {
+		lastByID[latest[i].QuestionnaireID] = &latest[i]
+	}
+
+	now := time.Now()
+	result := make([]dto.QuestionnaireSummary, 0, len(s.catalog))
+	for _, def := range s.catalog {
+		_, maxScore := questionnaireScoreRange(def)
+		summary := dto.QuestionnaireSummary{
+			ID:             def.ID,
+			Name:           def.Name,
+			Description:    def.Description,
+			ItemCount:      len(def.Items),
+			MaxScore:       maxScore,
+			HigherIsBetter: def.HigherIsBetter,
+			Schedule:       dto.QuestionnaireScheduleStatus{IntervalDays: def.IntervalDays},
+		}
+		if sched, ok := byID[def.ID]; ok {
+			summary.Schedule = scheduleStatus(sched, now)
+		}
+		if last, ok := lastByID[def.ID]; ok {
+			item := resultItem(last, false)
+			summary.LastResult = &item
+		}
+		result = append(result, summary)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+// Submit scores a completed questionnaire, stores it and moves the schedule on
+func (s *QuestionnaireService) Submit(userID uuid.UUID, id string, req *dto.SubmitQuestionnaireRequest, locale string) (*dto.QuestionnaireResultResponse, error) {
+	def, ok := s.catalog[id]
+	if !ok {
+		return nil, ErrQuestionnaireNotFound
+	}
+
+	valid := make(map[int]bool, len(def.Options))
+	lowest := def.Options[0].Value
+	for _, o := range def.Options {
+		valid[o.Value] = true
+		if o.Value < lowest {
+			lowest = o.Value
+		}
+	}
+
+	if len(req.Answers) != len(def.Items) {
+		return nil, fmt.Errorf("all %d items must be answered", len(def.Items))
+	}
+	sum, safety := 0, false
+	for _, item := range def.Items {
+		value, ok := req.Answers[item.ID]
+		if !ok {
+			return nil, fmt.Errorf("item %s must be answered", item.ID)
+		}
+		if !valid[value] {
+			return nil, fmt.Errorf("invalid answer for item %s", item.ID)
+		}
+		sum += value
+		if item.Safety && value > lowest {
+			safety = true
+		}
+	}
+
+	score := sum * def.Multiplier
+	_, maxScore := questionnaireScoreRange(def)
+	band := questionnaireBand(def, score)
+	now := time.Now()
+
+	result := models.QuestionnaireResult{
+		ID:              uuid.New(),
+		UserID:          userID,
+		QuestionnaireID: def.ID,
+		Version:         def.Version,
+		Answers:         models.QuestionnaireAnswers(req.Answers),
+		Score:           score,
+		MaxScore:        maxScore,
+		Severity:        band.Severity,
+		SeverityLabel:   band.Label,
+		SafetyFlag:      safety,
+		CompletedAt:     now,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&result).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.QuestionnaireSchedule{}).
+			Where("user_id = ? AND questionnaire_id = ?", userID, def.ID).
+			Updates(map[string]interface{}{
+				"next_due_at": gorm.Expr("? + make_interval(days => interval_days)", now),
+				"prompted_at": nil,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.QuestionnaireResultResponse{
+		QuestionnaireResultItem: resultItem(&result, true),
+		Resources:               []dto.WellbeingResource{},
+	}
+	if safety {
+		resp.Resources = s.wellbeing.Resources(locale)
+	}
+	return resp, nil
+}
+
+// ListResults returns the user's results for a questionnaire, newest first
+func (s *QuestionnaireService) ListResults(userID uuid.UUID, id string) ([]dto.QuestionnaireResultItem, error) {
+	if _, ok := s.catalog[id]; !ok {
+		return nil, ErrQuestionnaireNotFound
+	}
+
+	var results []models.QuestionnaireResult
+	err := s.db.Where("user_id = ? AND questionnaire_id = ?", userID, id).
+		Order("completed_at DESC").
+		Limit(maxQuestionnaireResults).
+		Find(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.QuestionnaireResultItem, 0, len(results))
+	for i := range results {
+		items = append(items, resultItem(&results[i], true))
+	}
+	return items, nil
+}
+
+// GetTrend returns scores over the range (30d, 90d, 1y) with check-in averages
+// over each result's lookback window
+func (s *QuestionnaireService) GetTrend(userID uuid.UUID, id, rangeKey string) (*dto.QuestionnaireTrendResponse, error) {
+	def, ok := s.catalog[id]
+	if !ok {
+		return nil, ErrQuestionnaireNotFound
+	}
+	from, today, err := insightWindow(rangeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		CompletedAt   time.Time
+		Score         int
+		Severity      string
+		SeverityLabel string
+		AvgFeel       *float64
+		AvgMood       *float64
+		AvgEnergy     *float64
+		CheckInCount  int64
+	}
+	err = s.db.Raw(`
+		SELECT r.completed_at, r.score, r.severity, r.severity_label,
+			AVG(f.feel_score) AS avg_feel, AVG(f.mood_score) AS avg_mood,
+			AVG(f.energy_score) AS avg_energy, COUNT(f.id) AS check_in_count
+		FROM questionnaire_results r
+		LEFT JOIN feel_checks f ON f.user_id = r.user_id AND f.deleted_at IS NULL
+			AND f.check_date > r.completed_at::date - ?::int AND f.check_date <= r.completed_at::date
+		WHERE r.user_id = ? AND r.questionnaire_id = ? AND r.completed_at >= ?
+		GROUP BY r.id
+		ORDER BY r.completed_at`, def.LookbackDays, userID, def.ID, from).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	_, maxScore := questionnaireScoreRange(def)
+	resp := &dto.QuestionnaireTrendResponse{
+		QuestionnaireID: def.ID,
+		Range:           rangeKey,
+		From:            from.Format("2006-01-02"),
+		To:              today.Format("2006-01-02"),
+		MaxScore:        maxScore,
+		HigherIsBetter:  def.HigherIsBetter,
+		Bands:           def.Bands,
+		Points:          make([]dto.QuestionnaireTrendPoint, 0, len(rows)),
+	}
+	for _, r := range rows {
+		resp.Points = append(resp.Points, dto.QuestionnaireTrendPoint{
+			Date:           r.CompletedAt.Format("2006-01-02"),
+			Score:          r.Score,
+			Severity:       r.Severity,
+			SeverityLabel:  r.SeverityLabel,
+			AvgFeelScore:   roundAverage(r.AvgFeel),
+			AvgMoodScore:   roundAverage(r.AvgMood),
+			AvgEnergyScore: roundAverage(r.AvgEnergy),
+			CheckInCount:   r.CheckInCount,
+		})
+	}
+	return resp, nil
+}
+
+// UpdateSchedule turns periodic prompts on or off for a questionnaire. The first
+// prompt is due one interval after the latest result, or right away.
+func (s *QuestionnaireService) UpdateSchedule(userID uuid.UUID, id string, req *dto.UpdateQuestionnaireScheduleRequest) (*dto.QuestionnaireScheduleStatus, error) {
+	def, ok := s.catalog[id]
+	if !ok {
+		return nil, ErrQuestionnaireNotFound
+	}
+
+	interval := req.IntervalDays
+	if interval == 0 {
+		interval = def.IntervalDays
+	}
+	if interval < minQuestionnaireInterval || interval > maxQuestionnaireInterval {
+		return nil, fmt.Errorf("interval_days must be between %d and %d", minQuestionnaireInterval, maxQuestionnaireInterval)
+	}
+
+	now := time.Now()
+	nextDue := now
+	var last models.QuestionnaireResult
+	err := s.db.Select("completed_at").
+		Where("user_id = ? AND questionnaire_id = ?", userID, id).
+		Order("completed_at DESC").
+		First(&last).Error
+	if err == nil {
+		nextDue = last.CompletedAt.AddDate(0, 0, interval)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	schedule := models.QuestionnaireSchedule{
+		UserID:          userID,
+		QuestionnaireID: id,
+		Enabled:         req.Enabled,
+		IntervalDays:    interval,
+		NextDueAt:       nextDue,
+	}
+	err = s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "questionnaire_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "interval_days", "next_due_at", "prompted_at", "updated_at"}),
+	}).Create(&schedule).Error
+	if err != nil {
+		return nil, err
+	}
+
+	status := scheduleStatus(&schedule, now)
+	return &status, nil
+}
+
+// RunScheduler sends due questionnaire prompts every interval until ctx is done
+func (s *QuestionnaireService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := s.SendDuePrompts(ctx, time.Now())
+		if err != nil {
+			log.Printf("Questionnaire prompt run failed: %v", err)
+		} else if sent > 0 {
+			log.Printf("Sent %d questionnaire prompts", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDuePrompts notifies users whose questionnaires are due, once per due date
+// and only during their local daytime. Each prompt is claimed by setting
+// prompted_at first, so concurrent instances never send twice.
+func (s *QuestionnaireService) SendDuePrompts(ctx context.Context, now time.Time) (int, error) {
+	sent := 0
+	var schedules []models.QuestionnaireSchedule
+	result := s.db.Preload("User").
+		Where("enabled = ? AND prompted_at IS NULL AND next_due_at <= ?", true, now).
+		FindInBatches(&schedules, 500, func(tx *gorm.DB, batch int) error {
+			for i := range schedules {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				sched := &schedules[i]
+				def, ok := s.catalog[sched.QuestionnaireID]
+				if !ok {
+					continue // Definition was removed from the catalog
+				}
+				hour := now.In(sched.User.Location()).Hour()
+				if hour < questionnairePromptFrom || hour >= questionnairePromptTo {
+					continue
+				}
+
+				ok, err := s.prompt(ctx, sched, def, now)
+				if err != nil {
+					log.Printf("Failed to send questionnaire prompt to user %s: %v", sched.UserID, err)
+					continue
+				}
+				if ok {
+					sent++
+				}
+			}
+			return nil
+		})
+
+	return sent, result.Error
+}
+
+// prompt claims the schedule's due date and sends the notification
+func (s *QuestionnaireService) prompt(ctx context.Context, sched *models.QuestionnaireSchedule, def *dto.QuestionnaireDefinition, now time.Time) (bool, error) {
+	claim := s.db.Model(&models.QuestionnaireSchedule{}).
+		Where("id = ? AND prompted_at IS NULL", sched.ID).
+		UpdateColumn("prompted_at", now)
+	if claim.Error != nil {
+		return false, claim.Error
+	}
+	if claim.RowsAffected == 0 {
+		return false, nil // Another instance has it
+	}
+
+	err := s.notifier.Notify(ctx, sched.UserID, Notification{
+		Title: "Time for your " + def.Name,
+		Body:  "A few questions about the last two weeks. It only takes a couple of minutes.",
+		Data:  map[string]string{"type": "questionnaire_due", "questionnaire_id": def.ID},
+	})
+	if err != nil {
+		// Release the claim so a later run can retry
+		s.db.Model(&models.QuestionnaireSchedule{}).Where("id = ?", sched.ID).UpdateColumn("prompted_at", nil)
+		return false, err
+	}
+	return true, nil
+}
+
+func scheduleStatus(sched *models.QuestionnaireSchedule, now time.Time) dto.QuestionnaireScheduleStatus {
+	status := dto.QuestionnaireScheduleStatus{
+		Enabled:      sched.Enabled,
+		IntervalDays: sched.IntervalDays,
+	}
+	if sched.Enabled {
+		next := sched.NextDueAt
+		status.NextDueAt = &next
+		status.Due = !next.After(now)
+	}
+	return status
+}
+
+func resultItem(r *models.QuestionnaireResult, withAnswers bool) dto.QuestionnaireResultItem {
+	item := dto.QuestionnaireResultItem{
+		ID:            r.ID.String(),
+		Version:       r.Version,
+		Score:         r.Score,
+		MaxScore:      r.MaxScore,
+		Severity:      r.Severity,
+		SeverityLabel: r.SeverityLabel,
+		SafetyFlag:    r.SafetyFlag,
+		CompletedAt:   r.CompletedAt,
+	}
+	if withAnswers {
+		item.Answers = r.Answers
+	}
+	return item
+}
+
+func roundAverage(v *float64) *float64 {
+	if v == nil || math.IsNaN(*v) {
+		return nil
+	}
+	r := round1(*v)
+	return &r
+}
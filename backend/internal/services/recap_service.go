@@ -0,0 +1,409 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const recapTopItems = 3
+
+var (
+	ErrInvalidRecapType  = errors.New("type must be week or month")
+	ErrInvalidRecapLimit = errors.New("limit must be between 1 and 52")
+)
+
+type RecapService struct {
+	db *gorm.DB
+}
+
+func NewRecapService(db *gorm.DB) *RecapService {
+	return &RecapService{db: db}
+}
+
+// RunScheduler generates due recaps immediately and then every interval until ctx is done.
+// Users are checked in their own time zone, so an hourly interval delivers each
+// recap shortly after the user's local week or month ends.
+func (s *RecapService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		created, err := s.GenerateDueRecaps(time.Now())
+		if err != nil {
+			log.Printf("Recap generation failed: %v", err)
+		} else if created > 0 {
+			log.Printf("Generated %d recaps", created)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GenerateDueRecaps builds the most recently completed weekly and monthly recap
+// for every user. Existing recaps are left untouched, so re-runs are idempotent.
+func (s *RecapService) GenerateDueRecaps(now time.Time) (int, error) {
+	created := 0
+	var users []models.User
+	result := s.db.Select("id", "timezone", "created_at").
+		FindInBatches(&users, 200, func(tx *gorm.DB, batch int) error {
+			for i := range users {
+				loc := users[i].Location()
+				for _, periodType := range []string{models.RecapWeekly, models.RecapMonthly} {
+					start, end := lastCompletedPeriod(periodType, now.In(loc))
+					if users[i].CreatedAt.After(localEnd(end, loc)) {
+						continue
+					}
+
+					ok, err := s.buildRecap(users[i].ID, loc, periodType, start, end)
+					if err != nil {
+						log.Printf("Failed to build %s recap for user %s: %v", periodType, users[i].ID, err)
+						continue
+					}
+					if ok {
+						created++
+					}
+				}
+			}
+			return nil
+		})
+
+	return created, result.Error
+}
+
+// ListRecaps returns the user's recaps, newest first, and marks the unread ones as read
+func (s *RecapService) ListRecaps(userID uuid.UUID, req *dto.RecapQuery) (*dto.RecapListResponse, error) {
+	if req.Type != "" && req.Type != models.RecapWeekly && req.Type != models.RecapMonthly {
+		return nil, ErrInvalidRecapType
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 12
+	}
+	if limit < 1 || limit > 52 {
+		return nil, ErrInvalidRecapLimit
+	}
+
+	query := s.db.Where("user_id = ?", userID)
+	if req.Type != "" {
+		query = query.Where("period_type = ?", req.Type)
+	}
+
+	var recaps []models.Recap
+	if err := query.Order("period_end DESC, period_type").Limit(limit).Find(&recaps).Error; err != nil {
+		return nil, err
+	}
+
+	resp := &dto.RecapListResponse{Data: make([]dto.RecapResponse, 0, len(recaps))}
+	var unreadIDs []uuid.UUID
+	for _, r := range recaps {
+		item := dto.RecapResponse{
+			ID:             r.ID.String(),
+			PeriodType:     r.PeriodType,
+			PeriodStart:    r.PeriodStart.Format("2006-01-02"),
+			PeriodEnd:      r.PeriodEnd.Format("2006-01-02"),
+			CheckIns:       r.CheckIns,
+			AvgScore:       r.AvgScore,
+			PrevAvgScore:   r.PrevAvgScore,
+			ScoreChange:    r.ScoreChange,
+			CurrentStreak:  r.CurrentStreak,
+			LongestStreak:  r.LongestStreak,
+			TopTags:        r.TopTags,
+			TopEmotions:    r.TopEmotions,
+			VibesReceived:  r.VibesReceived,
+			GratitudeDays:  r.GratitudeDays,
+			GratitudeItems: r.GratitudeItems,
+			BadgesEarned:   r.BadgesEarned,
+			IsNew:          r.ReadAt == nil,
+		}
+		if item.BadgesEarned == nil {
+			item.BadgesEarned = []string{}
+		}
+		if r.BestDay != nil && r.BestScore != nil {
+			item.BestDay = &dto.RecapDay{Date: r.BestDay.Format("2006-01-02"), FeelScore: *r.BestScore}
+		}
+		if r.WorstDay != nil && r.WorstScore != nil {
+			item.WorstDay = &dto.RecapDay{Date: r.WorstDay.Format("2006-01-02"), FeelScore: *r.WorstScore}
+		}
+		if r.ReadAt == nil {
+			unreadIDs = append(unreadIDs, r.ID)
+		}
+		resp.Data = append(resp.Data, item)
+	}
+
+	if len(unreadIDs) > 0 {
+		err := s.db.Model(&models.Recap{}).
+			Where("id IN ? AND read_at IS NULL", unreadIDs).
+			Update("read_at", time.Now()).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	resp.Unread = len(unreadIDs)
+
+	return resp, nil
+}
+
+// buildRecap computes and stores one recap. It returns false when the recap
+// already exists or the period had no activity.
+func (s *RecapService) buildRecap(userID uuid.UUID, loc *time.Location, periodType string, start, end time.Time) (bool, error) {
+	var existing int64
+	err := s.db.Model(&models.Recap{}).
+		Where("user_id = ? AND period_type = ? AND period_start = ?", userID, periodType, start).
+		Count(&existing).Error
+	if err != nil {
+		return false, err
+	}
+	if existing > 0 {
+		return false, nil
+	}
+
+	// Check-ins are bucketed by the local day they were made on
+	tz := loc.String()
+	localDay := "(created_at AT TIME ZONE ?)::date"
+	from, to := localStart(start, loc), localEnd(end, loc)
+
+	var vibes int64
+	err = s.db.Model(&models.GoodVibe{}).
+		Where("receiver_id = ? AND created_at >= ? AND created_at < ?", userID, from, to).
+		Count(&vibes).Error
+	if err != nil {
+		return false, err
+	}
+
+	var stats struct {
+		CheckIns int
+		AvgScore *float64
+	}
+	err = s.db.Model(&models.FeelCheck{}).
+		Select("COUNT(*) AS check_ins, AVG(feel_score) AS avg_score").
+		Where("user_id = ? AND "+localDay+" BETWEEN ? AND ?", userID, tz, start, end).
+		Scan(&stats).Error
+	if err != nil {
+		return false, err
+	}
+	var gratitude struct {
+		Days  int
+		Items int
+	}
+	err = s.db.Model(&models.GratitudeEntry{}).
+		Select("COUNT(*) AS days, COALESCE(SUM(item_count), 0) AS items").
+		Where("user_id = ? AND entry_date BETWEEN ? AND ?", userID, start, end).
+		Scan(&gratitude).Error
+	if err != nil {
+		return false, err
+	}
+
+	if stats.CheckIns == 0 && vibes == 0 && gratitude.Days == 0 {
+		return false, nil
+	}
+
+	recap := models.Recap{
+		UserID:         userID,
+		PeriodType:     periodType,
+		PeriodStart:    start,
+		PeriodEnd:      end,
+		CheckIns:       stats.CheckIns,
+		VibesReceived:  int(vibes),
+		GratitudeDays:  gratitude.Days,
+		GratitudeItems: gratitude.Items,
+		TopTags:        models.RecapItems{},
+		TopEmotions:    models.RecapItems{},
+		BadgesEarned:   []string{},
+	}
+
+	if stats.AvgScore != nil {
+		avg := round1(*stats.AvgScore)
+		recap.AvgScore = &avg
+
+		prevStart := previousPeriodStart(periodType, start)
+		var prevAvg *float64
+		err = s.db.Model(&models.FeelCheck{}).
+			Select("AVG(feel_score)").
+			Where("user_id = ? AND "+localDay+" BETWEEN ? AND ?", userID, tz, prevStart, start.AddDate(0, 0, -1)).
+			Scan(&prevAvg).Error
+		if err != nil {
+			return false, err
+		}
+		if prevAvg != nil {
+			prev := round1(*prevAvg)
+			change := round1(*stats.AvgScore - *prevAvg)
+			recap.PrevAvgScore = &prev
+			recap.ScoreChange = &change
+		}
+
+		recap.BestDay, recap.BestScore, err = s.extremeDay(userID, tz, start, end, "DESC")
+		if err != nil {
+			return false, err
+		}
+		recap.WorstDay, recap.WorstScore, err = s.extremeDay(userID, tz, start, end, "ASC")
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if err := s.fillStreaks(&recap, tz); err != nil {
+		return false, err
+	}
+
+	var tags []models.RecapItem
+	err = s.db.Raw(`
+		SELECT t.name AS key, t.name AS label, t.icon, COUNT(*) AS count
+		FROM feel_checks fc
+		JOIN feel_check_tags fct ON fct.feel_check_id = fc.id
+		JOIN tags t ON t.id = fct.tag_id AND t.deleted_at IS NULL
+		WHERE fc.user_id = ? AND fc.deleted_at IS NULL
+			AND (fc.created_at AT TIME ZONE ?)::date BETWEEN ? AND ?
+		GROUP BY t.name, t.icon
+		ORDER BY count DESC, t.name
+		LIMIT ?`, userID, tz, start, end, recapTopItems).
+		Scan(&tags).Error
+	if err != nil {
+		return false, err
+	}
+	recap.TopTags = append(recap.TopTags, tags...)
+
+	var emotions []struct {
+		EmotionID string
+		Count     int
+	}
+	err = s.db.Raw(`
+		SELECT fce.emotion_id, COUNT(*) AS count
+		FROM feel_check_emotions fce
+		JOIN feel_checks fc ON fc.id = fce.feel_check_id
+		WHERE fc.user_id = ? AND fc.deleted_at IS NULL
+			AND (fc.created_at AT TIME ZONE ?)::date BETWEEN ? AND ?
+		GROUP BY fce.emotion_id
+		ORDER BY count DESC, fce.emotion_id
+		LIMIT ?`, userID, tz, start, end, recapTopItems).
+		Scan(&emotions).Error
+	if err != nil {
+		return false, err
+	}
+	for _, e := range emotions {
+		item := models.RecapItem{Key: e.EmotionID, Label: e.EmotionID, Count: e.Count}
+		if emotion, ok := models.FindEmotion(e.EmotionID); ok {
+			item.Label = emotion.Label("en")
+			item.Icon = emotion.DefaultEmoji
+		}
+		recap.TopEmotions = append(recap.TopEmotions, item)
+	}
+
+	err = s.db.Model(&models.BadgeUnlock{}).
+		Where("user_id = ? AND unlocked_at >= ? AND unlocked_at < ?", userID, from, to).
+		Order("unlocked_at").
+		Pluck("badge", &recap.BadgesEarned).Error
+	if err != nil {
+		return false, err
+	}
+
+	// The unique index makes concurrent or repeated runs a no-op
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&recap)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// extremeDay returns the local day with the highest (DESC) or lowest (ASC) feel score
+func (s *RecapService) extremeDay(userID uuid.UUID, tz string, start, end time.Time, order string) (*time.Time, *int, error) {
+	var rows []struct {
+		Day       time.Time
+		FeelScore int
+	}
+	err := s.db.Model(&models.FeelCheck{}).
+		Select("(created_at AT TIME ZONE ?)::date AS day, feel_score", tz).
+		Where("user_id = ? AND (created_at AT TIME ZONE ?)::date BETWEEN ? AND ?", userID, tz, start, end).
+		Order("feel_score " + order + ", created_at").
+		Limit(1).
+		Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return nil, nil, err
+	}
+	return &rows[0].Day, &rows[0].FeelScore, nil
+}
+
+// fillStreaks sets the longest run of check-in days inside the period and the
+// streak as of its last day, using gaps-and-islands over local check-in days.
+func (s *RecapService) fillStreaks(recap *models.Recap, tz string) error {
+	var islands []struct {
+		StartDate time.Time
+		EndDate   time.Time
+		Days      int
+	}
+	err := s.db.Raw(`
+		WITH days AS (
+			SELECT DISTINCT (created_at AT TIME ZONE ?)::date AS day
+			FROM feel_checks
+			WHERE user_id = ? AND deleted_at IS NULL
+				AND (created_at AT TIME ZONE ?)::date <= ?
+		), islands AS (
+			SELECT day, day - (ROW_NUMBER() OVER (ORDER BY day))::int AS grp
+			FROM days
+		)
+		SELECT MIN(day) AS start_date, MAX(day) AS end_date, COUNT(*) AS days
+		FROM islands
+		GROUP BY grp
+		HAVING MAX(day) >= ?`, tz, recap.UserID, tz, recap.PeriodEnd, recap.PeriodStart).
+		Scan(&islands).Error
+	if err != nil {
+		return err
+	}
+
+	for _, island := range islands {
+		if island.EndDate.Equal(recap.PeriodEnd) {
+			recap.CurrentStreak = island.Days
+		}
+
+		from := island.StartDate
+		if from.Before(recap.PeriodStart) {
+			from = recap.PeriodStart
+		}
+		inPeriod := int(island.EndDate.Sub(from).Hours()/24) + 1
+		if inPeriod > recap.LongestStreak {
+			recap.LongestStreak = inPeriod
+		}
+	}
+	return nil
+}
+
+// lastCompletedPeriod returns the first and last day of the most recent full
+// week (Monday to Sunday) or month before localNow, as UTC-midnight dates
+func lastCompletedPeriod(periodType string, localNow time.Time) (time.Time, time.Time) {
+	today := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, time.UTC)
+	if periodType == models.RecapMonthly {
+		firstOfMonth := today.AddDate(0, 0, 1-today.Day())
+		return firstOfMonth.AddDate(0, -1, 0), firstOfMonth.AddDate(0, 0, -1)
+	}
+	monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	return monday.AddDate(0, 0, -7), monday.AddDate(0, 0, -1)
+}
+
+func previousPeriodStart(periodType string, start time.Time) time.Time {
+	if periodType == models.RecapMonthly {
+		return start.AddDate(0, -1, 0)
+	}
+	return start.AddDate(0, 0, -7)
+}
+
+// localStart is the instant the given date begins in loc
+func localStart(day time.Time, loc *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+}
+
+// localEnd is the instant the given date ends in loc (exclusive)
+func localEnd(day time.Time, loc *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxReminderTimes = 5
+	allWeekdays      = 127
+	// reminderWindow is how late a reminder may still go out, e.g. after a restart
+	reminderWindow = 15 * time.Minute
+)
+
+type ReminderService struct {
+	db       *gorm.DB
+	notifier Notifier
+}
+
+func NewReminderService(db *gorm.DB, notifier Notifier) *ReminderService {
+	return &ReminderService{db: db, notifier: notifier}
+}
+
+// GetSettings returns the user's reminder settings, or disabled defaults
+func (s *ReminderService) GetSettings(userID uuid.UUID) (*dto.ReminderSettingsResponse, error) {
+	var setting models.ReminderSetting
+	err := s.db.Where("user_id = ?", userID).First(&setting).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		var user models.User
+		if err := s.db.Select("timezone").First(&user, "id = ?", userID).Error; err != nil {
+			return nil, err
+		}
+		return &dto.ReminderSettingsResponse{
+			Times:    []string{},
+			Weekdays: weekdaysFromMask(allWeekdays),
+			Timezone: user.Location().String(),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reminderResponse(&setting), nil
+}
+
+// UpdateSettings replaces the user's reminder settings
+func (s *ReminderService) UpdateSettings(userID uuid.UUID, req *dto.ReminderSettingsRequest) (*dto.ReminderSettingsResponse, error) {
+	times, err := normalizeReminderTimes(req.Times)
+	if err != nil {
+		return nil, err
+	}
+	if req.Enabled && len(times) == 0 {
+		return nil, errors.New("at least one reminder time is required")
+	}
+
+	mask := 0
+	for _, d := range req.Weekdays {
+		if d < 1 || d > 7 {
+			return nil, errors.New("weekdays must be between 1 (Monday) and 7 (Sunday)")
+		}
+		mask |= 1 << uint(d%7)
+	}
+	if len(req.Weekdays) == 0 {
+		mask = allWeekdays
+	}
+
+	timezone := strings.TrimSpace(req.Timezone)
+	if timezone == "" {
+		var user models.User
+		if err := s.db.Select("timezone").First(&user, "id = ?", userID).Error; err != nil {
+			return nil, err
+		}
+		timezone = user.Location().String()
+	}
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "Local" {
+		return nil, ErrInvalidTimezone
+	}
+
+	setting := models.ReminderSetting{
+		UserID:   userID,
+		Enabled:  req.Enabled,
+		Times:    times,
+		Weekdays: mask,
+		Timezone: timezone,
+	}
+	err = s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "times", "weekdays", "timezone", "updated_at"}),
+	}).Create(&setting).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return reminderResponse(&setting), nil
+}
+
+// RunScheduler sends due reminders every interval until ctx is done
+func (s *ReminderService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := s.SendDueReminders(ctx, time.Now())
+		if err != nil {
+			log.Printf("Reminder run failed: %v", err)
+		} else if sent > 0 {
+			log.Printf("Sent %d check-in reminders", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDueReminders notifies users whose reminder time passed within the last
+// reminderWindow and who have not checked in today. Each slot is claimed with
+// an insert on a unique index first, so concurrent instances never send twice.
+func (s *ReminderService) SendDueReminders(ctx context.Context, now time.Time) (int, error) {
+	sent := 0
+	var settings []models.ReminderSetting
+	result := s.db.Where("enabled = ? AND cardinality(times) > 0", true).
+		FindInBatches(&settings, 500, func(tx *gorm.DB, batch int) error {
+			for i := range settings {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				slot, ok := dueReminderSlot(&settings[i], now)
+				if !ok {
+					continue
+				}
+
+				ok, err := s.deliver(ctx, &settings[i], slot)
+				if err != nil {
+					log.Printf("Failed to send reminder to user %s: %v", settings[i].UserID, err)
+					continue
+				}
+				if ok {
+					sent++
+				}
+			}
+			return nil
+		})
+
+	// Claims only matter within the window; keep two days for debugging
+	s.db.Where("created_at < ?", now.Add(-48*time.Hour)).Delete(&models.ReminderDelivery{})
+
+	return sent, result.Error
+}
+
+// deliver claims the slot and sends the reminder unless the user already checked in today
+func (s *ReminderService) deliver(ctx context.Context, setting *models.ReminderSetting, slot time.Time) (bool, error) {
+	// Check-ins are keyed by check_date: the server's day for CreateFeelCheck, the
+	// user's local day for offline sync. slot is in the user's time zone.
+	today := time.Now().Truncate(24 * time.Hour)
+	localToday := time.Date(slot.Year(), slot.Month(), slot.Day(), 0, 0, 0, 0, time.UTC)
+
+	var checkedIn int64
+	err := s.db.Model(&models.FeelCheck{}).
+		Where("user_id = ? AND check_date IN ?", setting.UserID, []time.Time{today, localToday}).
+		Count(&checkedIn).Error
+	if err != nil || checkedIn > 0 {
+		return false, err
+	}
+
+	claim := models.ReminderDelivery{UserID: setting.UserID, ScheduledFor: slot}
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&claim)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil // Another instance has it
+	}
+
+	err = s.notifier.Notify(ctx, setting.UserID, Notification{
+		Title: "How are you feeling?",
+		Body:  "Take a moment for today's check-in.",
+		Data:  map[string]string{"type": "check_in_reminder"},
+	})
+	if err != nil {
+		// Release the claim so a later run within the window can retry
+		s.db.Delete(&claim)
+		return false, err
+	}
+	return true, nil
+}
+
+// dueReminderSlot returns the latest reminder time in [now-reminderWindow, now]
+// for the user's local day, if that weekday is enabled
+func dueReminderSlot(setting *models.ReminderSetting, now time.Time) (time.Time, bool) {
+	loc, err := time.LoadLocation(setting.Timezone)
+	if err != nil {
+		return time.Time{}, false
+	}
+	local := now.In(loc)
+	if !setting.OnWeekday(local.Weekday()) {
+		return time.Time{}, false
+	}
+
+	var due time.Time
+	for _, t := range setting.Times {
+		at, err := time.Parse("15:04", t)
+		if err != nil {
+			continue
+		}
+		slot := time.Date(local.Year(), local.Month(), local.Day(), at.Hour(), at.Minute(), 0, 0, loc)
+		if !slot.After(now) && now.Sub(slot) < reminderWindow && slot.After(due) {
+			due = slot
+		}
+	}
+	return due, !due.IsZero()
+}
+
+func normalizeReminderTimes(raw []string) ([]string, error) {
+	seen := make(map[string]bool, len(raw))
+	times := make([]string, 0, len(raw))
+	for _, t := range raw {
+		parsed, err := time.Parse("15:04", strings.TrimSpace(t))
+		if err != nil {
+			return nil, errors.New("times must be in HH:MM format")
+		}
+		formatted := parsed.Format("15:04")
+		if !seen[formatted] {
+			seen[formatted] = true
+			times = append(times, formatted)
+		}
+	}
+	if len(times) > maxReminderTimes {
+		return nil, errors.New("at most 5 reminder times are allowed")
+	}
+	sort.Strings(times)
+	return times, nil
+}
+
+// weekdaysFromMask converts the Sunday-based bitmask to ISO weekdays
+func weekdaysFromMask(mask int) []int {
+	days := []int{}
+	for d := 1; d <= 7; d++ {
+		if mask&(1<<uint(d%7)) != 0 {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+func reminderResponse(setting *models.ReminderSetting) *dto.ReminderSettingsResponse {
+	times := setting.Times
+	if times == nil {
+		times = []string{}
+	}
+	return &dto.ReminderSettingsResponse{
+		Enabled:  setting.Enabled,
+		Times:    times,
+		Weekdays: weekdaysFromMask(setting.Weekdays),
+		Timezone: setting.Timezone,
+	}
+}
@@ -0,0 +1,303 @@
+package services
+
+import (
+	"errors"
+	"html"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	searchBatchSize = 200  // Check-ins decrypted per query
+	searchScanLimit = 1000 // Check-ins one request may decrypt before returning a cursor
+	snippetWords    = 20   // Longest snippet, in words
+)
+
+var ErrEmptySearchQuery = errors.New("search query is required")
+
+type SearchService struct {
+	db    *gorm.DB
+	notes *NoteCipher
+}
+
+func NewSearchService(db *gorm.DB, notes *NoteCipher) *SearchService {
+	return &SearchService{db: db, notes: notes}
+}
+
+// SearchFeels finds the user's check-ins whose notes match q, newest first.
+// Notes are encrypted at rest and never indexed, so they are decrypted and
+// matched here; plaintext never goes back to the database. A request scans at
+// most searchScanLimit check-ins, so a page can be short while HasMore is set.
+func (s *SearchService) SearchFeels(userID uuid.UUID, req *dto.FeelSearchQuery) (*dto.FeelSearchResponse, error) {
+	q := strings.TrimSpace(req.Q)
+	if utf8.RuneCountInString(q) > 200 {
+		return nil, errors.New("search query must be at most 200 characters")
+	}
+	query := parseNoteQuery(q)
+	if len(query.phrases) == 0 {
+		return nil, ErrEmptySearchQuery
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 20
+	}
+	if limit < 1 || limit > 50 {
+		return nil, errors.New("limit must be between 1 and 50")
+	}
+
+	filter, err := parseFeelFilter(req.From, req.To, req.MinScore, req.MaxScore, req.Emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursorDate time.Time
+	var cursorID uuid.UUID
+	if req.Cursor != "" {
+		if cursorDate, cursorID, err = decodeCursor(req.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		checks    []models.FeelCheck
+		snippets  []string
+		scanned   int
+		exhausted bool
+	)
+	for len(checks) <= limit && scanned < searchScanLimit {
+		size := searchBatchSize
+		if left := searchScanLimit - scanned; left < size {
+			size = left
+		}
+
+		batchQuery := filter.apply(s.db.Where("user_id = ?", userID).
+			Where("(encrypted_note IS NOT NULL OR note <> '')"))
+		if cursorID != uuid.Nil {
+			batchQuery = batchQuery.Where("(check_date, id) < (?, ?)", cursorDate, cursorID)
+		}
+
+		var batch []models.FeelCheck
+		err := batchQuery.Order("check_date DESC, id DESC").
+			Limit(size).
+			Find(&batch).Error
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) > 0 {
+			last := batch[len(batch)-1]
+			cursorDate, cursorID = last.CheckDate, last.ID
+		}
+
+		if err := s.notes.DecryptNotes(userID, batch); err != nil {
+			return nil, err
+		}
+		for _, check := range batch {
+			if snippet, ok := query.match(check.Note); ok {
+				checks = append(checks, check)
+				snippets = append(snippets, snippet)
+			}
+		}
+
+		scanned += len(batch)
+		if len(batch) < size {
+			exhausted = true
+			break
+		}
+	}
+
+	resp := &dto.FeelSearchResponse{Data: []dto.FeelSearchResult{}}
+	switch {
+	case len(checks) > limit:
+		checks = checks[:limit]
+		last := checks[len(checks)-1]
+		resp.HasMore = true
+		resp.NextCursor = encodeCursor(last.CheckDate, last.ID)
+	case !exhausted:
+		// Scan limit reached; the next page continues after the last check-in looked at
+		resp.HasMore = true
+		resp.NextCursor = encodeCursor(cursorDate, cursorID)
+	}
+
+	for i, check := range checks {
+		resp.Data = append(resp.Data, dto.FeelSearchResult{
+			ID:          check.ID.String(),
+			CheckDate:   check.CheckDate.Format("2006-01-02"),
+			MoodScore:   check.MoodScore,
+			EnergyScore: check.EnergyScore,
+			FeelScore:   check.FeelScore,
+			MoodEmoji:   check.MoodEmoji,
+			ColorHex:    check.ColorHex,
+			Snippet:     snippets[i],
+		})
+	}
+
+	return resp, nil
+}
+
+// noteQuery is a parsed search query. Every phrase must appear in the note and
+// no excluded word may. Words match case-insensitively by prefix, so "run"
+// finds "running".
+type noteQuery struct {
+	phrases  [][]string // A plain term is a one-word phrase
+	excluded []string
+}
+
+// noteWord is a word of a note, lowercased, with its byte offsets in the note
+type noteWord struct {
+	text       string
+	start, end int
+}
+
+// parseNoteQuery reads terms, "quoted phrases" and -excluded terms
+func parseNoteQuery(q string) noteQuery {
+	var query noteQuery
+	for q = strings.TrimSpace(q); q != ""; q = strings.TrimSpace(q) {
+		var field string
+		quoted := q[0] == '"'
+		if quoted {
+			q = q[1:]
+			end := strings.IndexByte(q, '"')
+			if end == -1 {
+				end = len(q)
+			}
+			field, q = q[:end], strings.TrimPrefix(q[end:], `"`)
+		} else {
+			end := strings.IndexFunc(q, unicode.IsSpace)
+			if end == -1 {
+				end = len(q)
+			}
+			field, q = q[:end], q[end:]
+		}
+
+		if !quoted && strings.HasPrefix(field, "-") {
+			for _, w := range noteWords(field[1:]) {
+				query.excluded = append(query.excluded, w.text)
+			}
+			continue
+		}
+
+		// Words split by punctuation, like well-being, must stay next to each other
+		words := noteWords(field)
+		if len(words) == 0 {
+			continue
+		}
+		phrase := make([]string, len(words))
+		for i, w := range words {
+			phrase[i] = w.text
+		}
+		query.phrases = append(query.phrases, phrase)
+	}
+	return query
+}
+
+// match reports whether note matches the query and returns its snippet
+func (q noteQuery) match(note string) (string, bool) {
+	words := noteWords(note)
+	for _, w := range words {
+		for _, ex := range q.excluded {
+			if strings.HasPrefix(w.text, ex) {
+				return "", false
+			}
+		}
+	}
+
+	hits := make([]bool, len(words))
+	for _, phrase := range q.phrases {
+		found := false
+		for i := 0; i+len(phrase) <= len(words); i++ {
+			matched := true
+			for j, term := range phrase {
+				if !strings.HasPrefix(words[i+j].text, term) {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				found = true
+				for j := range phrase {
+					hits[i+j] = true
+				}
+			}
+		}
+		if !found {
+			return "", false
+		}
+	}
+
+	return snippet(note, words, hits), true
+}
+
+// noteWords splits text into lowercased words of letters and digits
+func noteWords(text string) []noteWord {
+	var words []noteWord
+	start := -1
+	for i, r := range text {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case inWord && start == -1:
+			start = i
+		case !inWord && start != -1:
+			words = append(words, noteWord{text: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start != -1 {
+		words = append(words, noteWord{text: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+	return words
+}
+
+// snippet renders up to snippetWords words around the first hit, HTML-escaped,
+// with matched words wrapped in <b></b>
+func snippet(note string, words []noteWord, hits []bool) string {
+	first := 0
+	for i, hit := range hits {
+		if hit {
+			first = i
+			break
+		}
+	}
+
+	from, to := 0, len(words)
+	if len(words) > snippetWords {
+		from = first - snippetWords/4
+		if from < 0 {
+			from = 0
+		}
+		if from > len(words)-snippetWords {
+			from = len(words) - snippetWords
+		}
+		to = from + snippetWords
+	}
+
+	var b strings.Builder
+	pos := 0
+	if from > 0 {
+		b.WriteString("… ")
+		pos = words[from].start
+	}
+	for i := from; i < to; i++ {
+		w := words[i]
+		b.WriteString(html.EscapeString(note[pos:w.start]))
+		if hits[i] {
+			b.WriteString("<b>" + html.EscapeString(note[w.start:w.end]) + "</b>")
+		} else {
+			b.WriteString(html.EscapeString(note[w.start:w.end]))
+		}
+		pos = w.end
+	}
+	if to < len(words) {
+		b.WriteString(" …")
+	} else {
+		b.WriteString(html.EscapeString(note[pos:]))
+	}
+	return b.String()
+}
@@ -0,0 +1,321 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultShareDays   = 14
+	maxShareDays       = 90
+	maxActiveShares    = 20
+	maxShareAccessList = 200
+)
+
+// Shared resources recorded in the access log
+const (
+	ShareResourceGrant    = "grant"
+	ShareResourceHistory  = "history"
+	ShareResourceInsights = "insights"
+)
+
+var (
+	ErrShareGrantNotFound = errors.New("share link not found")
+	ErrShareGrantInactive = errors.New("share link has expired or been revoked")
+	ErrTooManyShareGrants = errors.New("at most 20 active share links are allowed")
+)
+
+type ShareService struct {
+	db       *gorm.DB
+	feels    *FeelService
+	insights *InsightsService
+	baseURL  string
+}
+
+// NewShareService builds share URLs on baseURL, the server's public address.
+// When empty, the address the request came in on is used.
+func NewShareService(db *gorm.DB, feels *FeelService, insights *InsightsService, baseURL string) *ShareService {
+	return &ShareService{db: db, feels: feels, insights: insights, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// CreateGrant issues a new share link for part of the user's history
+func (s *ShareService) CreateGrant(userID uuid.UUID, req *dto.CreateShareGrantRequest, requestBaseURL string) (*dto.ShareGrantResponse, error) {
+	label := strings.TrimSpace(req.Label)
+	if len([]rune(label)) > 100 {
+		return nil, errors.New("label must be at most 100 characters")
+	}
+
+	from, err := time.Parse("2006-01-02", req.From)
+	if err != nil {
+		return nil, errors.New("from must be a date (YYYY-MM-DD)")
+	}
+	var to *time.Time
+	if req.To != "" {
+		t, err := time.Parse("2006-01-02", req.To)
+		if err != nil {
+			return nil, errors.New("to must be a date (YYYY-MM-DD)")
+		}
+		if t.Before(from) {
+			return nil, errors.New("from must not be after to")
+		}
+		to = &t
+	}
+
+	days := req.ExpiresInDays
+	if days == 0 {
+		days = defaultShareDays
+	}
+	if days < 1 || days > maxShareDays {
+		return nil, fmt.Errorf("expires_in_days must be between 1 and %d", maxShareDays)
+	}
+
+	now := time.Now()
+	var active int64
+	err = s.db.Model(&models.ShareGrant{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Count(&active).Error
+	if err != nil {
+		return nil, err
+	}
+	if active >= maxActiveShares {
+		return nil, ErrTooManyShareGrants
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	grant := models.ShareGrant{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Label:        label,
+		TokenHash:    hashToken(token),
+		FromDate:     from,
+		ToDate:       to,
+		IncludeNotes: req.IncludeNotes,
+		ExpiresAt:    now.AddDate(0, 0, days),
+	}
+	if err := s.db.Create(&grant).Error; err != nil {
+		return nil, err
+	}
+
+	baseURL := s.baseURL
+	if baseURL == "" {
+		baseURL = strings.TrimRight(requestBaseURL, "/")
+	}
+	resp := shareGrantResponse(&grant, 0, now)
+	resp.URL = baseURL + "/api/shared/" + token
+	return resp, nil
+}
+
+// ListGrants returns the user's share links, newest first, with access counts
+func (s *ShareService) ListGrants(userID uuid.UUID) ([]dto.ShareGrantResponse, error) {
+	var grants []models.ShareGrant
+	if err := s.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&grants).Error; err != nil {
+		return nil, err
+	}
+
+	var counts []struct {
+		GrantID uuid.UUID
+		Count   int64
+	}
+	err := s.db.Model(&models.ShareAccess{}).
+		Select("grant_id, COUNT(*) AS count").
+		Where("user_id = ?", userID).
+		Group("grant_id").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	byGrant := make(map[uuid.UUID]int64, len(counts))
+	for _, c := range counts {
+		byGrant[c.GrantID] = c.Count
+	}
+
+	now := time.Now()
+	result := make([]dto.ShareGrantResponse, 0, len(grants))
+	for i := range grants {
+		result = append(result, *shareGrantResponse(&grants[i], byGrant[grants[i].ID], now))
+	}
+	return result, nil
+}
+
+// RevokeGrant disables a share link immediately
+func (s *ShareService) RevokeGrant(userID, grantID uuid.UUID) error {
+	result := s.db.Model(&models.ShareGrant{}).
+		Where("id = ? AND user_id = ?", grantID, userID).
+		Update("revoked_at", gorm.Expr("COALESCE(revoked_at, ?)", time.Now()))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrShareGrantNotFound
+	}
+	return nil
+}
+
+// ListAccesses returns the most recent uses of one of the user's share links
+func (s *ShareService) ListAccesses(userID, grantID uuid.UUID) ([]dto.ShareAccessResponse, error) {
+	var count int64
+	if err := s.db.Model(&models.ShareGrant{}).Where("id = ? AND user_id = ?", grantID, userID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, ErrShareGrantNotFound
+	}
+
+	var accesses []models.ShareAccess
+	err := s.db.Where("grant_id = ?", grantID).
+		Order("accessed_at DESC").
+		Limit(maxShareAccessList).
+		Find(&accesses).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.ShareAccessResponse, 0, len(accesses))
+	for _, a := range accesses {
+		result = append(result, dto.ShareAccessResponse{
+			Resource:   a.Resource,
+			IP:         a.IP,
+			UserAgent:  a.UserAgent,
+			AccessedAt: a.AccessedAt,
+		})
+	}
+	return result, nil
+}
+
+// OpenGrant resolves a share token and records the access in the owner's log
+func (s *ShareService) OpenGrant(token, resource, ip, userAgent string) (*models.ShareGrant, error) {
+	var grant models.ShareGrant
+	err := s.db.Where("token_hash = ?", hashToken(token)).First(&grant).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrShareGrantNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !grant.Active(now) {
+		return nil, ErrShareGrantInactive
+	}
+
+	if ua := []rune(userAgent); len(ua) > 255 {
+		userAgent = string(ua[:255])
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&grant).UpdateColumn("last_accessed_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.ShareAccess{
+			GrantID:    grant.ID,
+			UserID:     grant.UserID,
+			Resource:   resource,
+			IP:         ip,
+			UserAgent:  userAgent,
+			AccessedAt: now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &grant, nil
+}
+
+// SharedView describes the scope of an opened grant
+func (s *ShareService) SharedView(grant *models.ShareGrant) *dto.SharedViewResponse {
+	from, to := shareWindow(grant)
+	return &dto.SharedViewResponse{
+		Label:        grant.Label,
+		From:         from.Format("2006-01-02"),
+		To:           to.Format("2006-01-02"),
+		IncludeNotes: grant.IncludeNotes,
+		ExpiresAt:    grant.ExpiresAt,
+	}
+}
+
+// ParseSharedHistoryQuery validates a history query and limits it to the
+// grant's date range. Notes are left out unless the grant includes them.
+func (s *ShareService) ParseSharedHistoryQuery(grant *models.ShareGrant, req *dto.FeelHistoryQuery) (*FeelHistoryOptions, error) {
+	opts, err := s.feels.ParseHistoryQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	if !grant.IncludeNotes {
+		delete(opts.Fields, "note")
+	}
+
+	from, to := shareWindow(grant)
+	if opts.Filter.From == nil || opts.Filter.From.Before(from) {
+		opts.Filter.From = &from
+	}
+	if opts.Filter.To == nil || opts.Filter.To.After(to) {
+		opts.Filter.To = &to
+	}
+	return opts, nil
+}
+
+// SharedHistory returns a page of the grant owner's history
+func (s *ShareService) SharedHistory(grant *models.ShareGrant, opts *FeelHistoryOptions) (*dto.FeelHistoryResponse, error) {
+	return s.feels.GetFeelHistory(grant.UserID, opts)
+}
+
+// SharedInsights returns mood trends over the grant's date range
+func (s *ShareService) SharedInsights(grant *models.ShareGrant) (*dto.FeelInsightsResponse, error) {
+	from, to := shareWindow(grant)
+	resp, err := s.insights.GetInsightsBetween(grant.UserID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	resp.Range = "custom"
+	return resp, nil
+}
+
+// shareWindow resolves the grant's date range; open ranges end today
+func shareWindow(grant *models.ShareGrant) (time.Time, time.Time) {
+	to := time.Now().Truncate(24 * time.Hour)
+	if grant.ToDate != nil && grant.ToDate.Before(to) {
+		to = *grant.ToDate
+	}
+	return grant.FromDate, to
+}
+
+func shareGrantResponse(grant *models.ShareGrant, accessCount int64, now time.Time) *dto.ShareGrantResponse {
+	status := "active"
+	switch {
+	case grant.RevokedAt != nil:
+		status = "revoked"
+	case !grant.Active(now):
+		status = "expired"
+	}
+
+	resp := &dto.ShareGrantResponse{
+		ID:             grant.ID.String(),
+		Label:          grant.Label,
+		From:           grant.FromDate.Format("2006-01-02"),
+		IncludeNotes:   grant.IncludeNotes,
+		Status:         status,
+		ExpiresAt:      grant.ExpiresAt,
+		RevokedAt:      grant.RevokedAt,
+		LastAccessedAt: grant.LastAccessedAt,
+		AccessCount:    accessCount,
+		CreatedAt:      grant.CreatedAt,
+	}
+	if grant.ToDate != nil {
+		to := grant.ToDate.Format("2006-01-02")
+		resp.To = &to
+	}
+	return resp
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/config"
+)
+
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectStorage keeps uploaded files such as check-in photos. Keys are
+// slash-separated paths; objects are private and only reachable through
+// short-lived signed URLs.
+type ObjectStorage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Delete(ctx context.Context, key string) error
+	SignedURL(key string, expiry time.Duration) (string, error)
+}
+
+// NewObjectStorage returns the storage backend named by cfg.StorageBackend
+func NewObjectStorage(cfg *config.Config) (ObjectStorage, error) {
+	switch cfg.StorageBackend {
+	case "", "local":
+		return NewLocalStorage(cfg.StorageDir, cfg.PublicBaseURL, cfg.MediaSigningKey)
+	case "s3":
+		return NewS3Storage(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3PathStyle)
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s", cfg.StorageBackend)
+	}
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTagNotFound        = errors.New("tag not found")
+	ErrTagExists          = errors.New("tag with this name already exists")
+	ErrDefaultTagReadOnly = errors.New("default tags cannot be modified")
+	ErrInvalidTag         = errors.New("one or more tags are invalid")
+)
+
+var validTagCategories = map[string]bool{"activity": true, "context": true, "health": true}
+
+type TagService struct {
+	db *gorm.DB
+}
+
+func NewTagService(db *gorm.DB) *TagService {
+	return &TagService{db: db}
+}
+
+// ListTags returns the default tags plus the user's own tags
+func (s *TagService) ListTags(userID uuid.UUID) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := s.db.Where("is_default = ? OR user_id = ?", true, userID).
+		Order("is_default DESC, category, name").
+		Find(&tags).Error
+	return tags, err
+}
+
+func (s *TagService) CreateTag(userID uuid.UUID, req *dto.CreateTagRequest) (*models.Tag, error) {
+	name, category, err := validateTag(req.Name, req.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.nameTaken(userID, name, uuid.Nil) {
+		return nil, ErrTagExists
+	}
+
+	tag := models.Tag{
+		ID:       uuid.New(),
+		UserID:   &userID,
+		Name:     name,
+		Category: category,
+		Icon:     req.Icon,
+	}
+
+	if err := s.db.Create(&tag).Error; err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (s *TagService) UpdateTag(userID, tagID uuid.UUID, req *dto.UpdateTagRequest) (*models.Tag, error) {
+	tag, err := s.ownTag(userID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, category, err := validateTag(req.Name, req.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.nameTaken(userID, name, tag.ID) {
+		return nil, ErrTagExists
+	}
+
+	tag.Name = name
+	tag.Category = category
+	tag.Icon = req.Icon
+
+	if err := s.db.Save(tag).Error; err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// DeleteTag removes a user-defined tag and detaches it from all check-ins
+func (s *TagService) DeleteTag(userID, tagID uuid.UUID) error {
+	tag, err := s.ownTag(userID, tagID)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM feel_check_tags WHERE tag_id = ?", tag.ID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(tag).Error
+	})
+}
+
+// ownTag loads a tag the user is allowed to modify
+func (s *TagService) ownTag(userID, tagID uuid.UUID) (*models.Tag, error) {
+	var tag models.Tag
+	if err := s.db.Where("id = ?", tagID).First(&tag).Error; err != nil {
+		return nil, ErrTagNotFound
+	}
+	if tag.IsDefault {
+		return nil, ErrDefaultTagReadOnly
+	}
+	if tag.UserID == nil || *tag.UserID != userID {
+		return nil, ErrTagNotFound
+	}
+	return &tag, nil
+}
+
+func (s *TagService) nameTaken(userID uuid.UUID, name string, exceptID uuid.UUID) bool {
+	var count int64
+	s.db.Model(&models.Tag{}).
+		Where("(is_default = ? OR user_id = ?) AND LOWER(name) = LOWER(?) AND id <> ?", true, userID, name, exceptID).
+		Count(&count)
+	return count > 0
+}
+
+func validateTag(name, category string) (string, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 50 {
+		return "", "", errors.New("name is required and must be at most 50 characters")
+	}
+
+	if category == "" {
+		category = "activity"
+	}
+	if !validTagCategories[category] {
+		return "", "", errors.New("invalid category: must be activity, context, or health")
+	}
+
+	return name, category, nil
+}
+
+// findUsableTags loads tags by ID, ensuring each is a default tag or owned by the user
+func findUsableTags(db *gorm.DB, userID uuid.UUID, rawIDs []string) ([]models.Tag, error) {
+	if len(rawIDs) == 0 {
+		return []models.Tag{}, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(rawIDs))
+	seen := make(map[uuid.UUID]bool, len(rawIDs))
+	for _, raw := range rawIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, ErrInvalidTag
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	var tags []models.Tag
+	err := db.Where("id IN ? AND (is_default = ? OR user_id = ?)", ids, true, userID).
+		Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) != len(ids) {
+		return nil, ErrInvalidTag
+	}
+
+	return tags, nil
+}
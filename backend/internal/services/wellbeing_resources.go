@@ -0,0 +1,115 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+)
+
+// ResourceDirectory maps a locale ("en", "en-us", "tr") to tips and helplines.
+// Regional keys carry helplines; language keys carry tips and general helplines.
+type ResourceDirectory map[string][]dto.WellbeingResource
+
+// defaultResourceDirectory is used when WELLBEING_RESOURCES_FILE is not set
+var defaultResourceDirectory = ResourceDirectory{
+	"en": {
+		{Kind: "tip", Title: "Take a slow breath", Body: "Breathe in for 4 seconds, hold for 4, and breathe out for 6. Repeat a few times."},
+		{Kind: "tip", Title: "Reach out to someone", Body: "A short message to a friend or family member can help more than you expect."},
+		{Kind: "tip", Title: "Be gentle with yourself", Body: "Low days happen. Try one small thing that usually helps, like a walk, water or rest."},
+		{Kind: "helpline", Title: "Find a helpline", Body: "Free, confidential support in your country.", URL: "https://findahelpline.com"},
+	},
+	"en-us": {
+		{Kind: "helpline", Title: "988 Suicide & Crisis Lifeline", Body: "Call or text 988, available 24/7.", Phone: "988", URL: "https://988lifeline.org"},
+	},
+	"en-gb": {
+		{Kind: "helpline", Title: "Samaritans", Body: "Call free any time, day or night.", Phone: "116 123", URL: "https://www.samaritans.org"},
+	},
+	"tr": {
+		{Kind: "tip", Title: "Yavaşça nefes al", Body: "4 saniye nefes al, 4 saniye tut, 6 saniyede ver. Birkaç kez tekrarla."},
+		{Kind: "tip", Title: "Birine ulaş", Body: "Bir arkadaşına ya da ailene kısa bir mesaj beklediğinden fazla iyi gelebilir."},
+		{Kind: "tip", Title: "Kendine nazik davran", Body: "Zor günler olur. Genelde iyi gelen küçük bir şey dene: yürüyüş, su ya da dinlenmek."},
+		{Kind: "helpline", Title: "Acil Çağrı Merkezi", Body: "Acil durumlarda 7/24 ulaşabilirsin.", Phone: "112"},
+	},
+	"es": {
+		{Kind: "tip", Title: "Respira despacio", Body: "Inhala durante 4 segundos, mantén 4 y exhala en 6. Repite varias veces."},
+		{Kind: "tip", Title: "Habla con alguien", Body: "Un mensaje corto a un amigo o familiar puede ayudar más de lo que crees."},
+		{Kind: "tip", Title: "Sé amable contigo", Body: "Los días difíciles pasan. Prueba algo pequeño que suela ayudarte, como caminar, beber agua o descansar."},
+		{Kind: "helpline", Title: "Encuentra una línea de ayuda", Body: "Apoyo gratuito y confidencial en tu país.", URL: "https://findahelpline.com"},
+	},
+	"es-es": {
+		{Kind: "helpline", Title: "Línea 024", Body: "Atención a la conducta suicida, 24 horas.", Phone: "024"},
+	},
+	"es-mx": {
+		{Kind: "helpline", Title: "Línea de la Vida", Body: "Atención gratuita las 24 horas.", Phone: "800 911 2000"},
+	},
+}
+
+// loadResourceDirectory reads a directory from a JSON file, or returns the default
+func loadResourceDirectory(path string) (ResourceDirectory, error) {
+	if path == "" {
+		return defaultResourceDirectory, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wellbeing resources: %w", err)
+	}
+
+	var dir ResourceDirectory
+	if err := json.Unmarshal(raw, &dir); err != nil {
+		return nil, fmt.Errorf("invalid wellbeing resources file: %w", err)
+	}
+
+	normalized := make(ResourceDirectory, len(dir))
+	for locale, resources := range dir {
+		normalized[normalizeLocale(locale)] = resources
+	}
+	return normalized, nil
+}
+
+// Resources picks tips for the locale's language and helplines for its region,
+// falling back to the language and then to English
+func (d ResourceDirectory) Resources(locale string) []dto.WellbeingResource {
+	locale = normalizeLocale(locale)
+	candidates := []string{locale}
+	if lang, _, ok := strings.Cut(locale, "-"); ok {
+		candidates = append(candidates, lang)
+	}
+	candidates = append(candidates, "en")
+
+	var tips, helplines []dto.WellbeingResource
+	for _, key := range candidates {
+		var t, h []dto.WellbeingResource
+		for _, r := range d[key] {
+			if r.Kind == "helpline" {
+				h = append(h, r)
+			} else {
+				t = append(t, r)
+			}
+		}
+		if tips == nil && len(t) > 0 {
+			tips = t
+		}
+		if helplines == nil && len(h) > 0 {
+			helplines = h
+		}
+	}
+
+	return append(tips, helplines...)
+}
+
+// normalizeLocale turns "en_US" or "en-US,en;q=0.9" into "en-us"
+func normalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, ",;"); i >= 0 {
+		locale = locale[:i]
+	}
+	locale = strings.ReplaceAll(locale, "_", "-")
+	if locale == "" {
+		return "en"
+	}
+	return locale
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/config"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	wellbeingWindow      = 7                  // Check-ins considered for drops and volatility
+	wellbeingMinHistory  = 4                  // Check-ins needed before drops and volatility apply
+	wellbeingGlobalQuiet = 24 * time.Hour     // Minimum time between any two alerts
+	wellbeingActiveFor   = 7 * 24 * time.Hour // How long an undismissed alert stays visible
+)
+
+var ErrAlertNotFound = errors.New("alert not found")
+
+type WellbeingService struct {
+	db        *gorm.DB
+	cfg       *config.Config
+	resources ResourceDirectory
+}
+
+func NewWellbeingService(db *gorm.DB, cfg *config.Config) (*WellbeingService, error) {
+	resources, err := loadResourceDirectory(cfg.WellbeingResourcesFile)
+	if err != nil {
+		return nil, err
+	}
+	return &WellbeingService{db: db, cfg: cfg, resources: resources}, nil
+}
+
+// Evaluate looks at the user's recent check-ins after a new one and records at most
+// one alert, preferring sustained low mood over sharp drops over volatility.
+// Cooldowns keep alerts from repeating.
+func (s *WellbeingService) Evaluate(userID uuid.UUID) (*models.WellbeingAlert, error) {
+	var recent []models.FeelCheck
+	err := s.db.Select("id", "feel_score", "check_date").
+		Where("user_id = ? AND check_date >= ?", userID, time.Now().Truncate(24*time.Hour).AddDate(0, 0, -14)).
+		Order("check_date DESC, created_at DESC").
+		Limit(wellbeingWindow + 1).
+		Find(&recent).Error
+	if err != nil || len(recent) == 0 {
+		return nil, err
+	}
+
+	latest := recent[0]
+	alert := &models.WellbeingAlert{UserID: userID, FeelCheckID: &latest.ID, FeelScore: latest.FeelScore}
+
+	switch {
+	case s.sustainedLow(recent, alert):
+	case s.sharpDrop(recent, alert):
+	case s.highVolatility(recent, alert):
+	default:
+		return nil, nil
+	}
+
+	cooling, err := s.coolingDown(userID, alert.Kind)
+	if err != nil || cooling {
+		return nil, err
+	}
+
+	if err := s.db.Create(alert).Error; err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+// GetWellbeing returns the user's active alerts with resources for the locale
+func (s *WellbeingService) GetWellbeing(userID uuid.UUID, locale string) (*dto.WellbeingResponse, error) {
+	var alerts []models.WellbeingAlert
+	err := s.db.Where("user_id = ? AND dismissed_at IS NULL AND created_at >= ?", userID, time.Now().Add(-wellbeingActiveFor)).
+		Order("created_at DESC").
+		Find(&alerts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.WellbeingResponse{Alerts: alerts, Resources: []dto.WellbeingResource{}}
+	if len(alerts) > 0 {
+		resp.Resources = s.resources.Resources(locale)
+	}
+	return resp, nil
+}
+
+// Resources returns tips and helplines for the locale
+func (s *WellbeingService) Resources(locale string) []dto.WellbeingResource {
+	return s.resources.Resources(locale)
+}
+
+// DismissAlert hides an alert; its cooldown still applies
+func (s *WellbeingService) DismissAlert(userID, alertID uuid.UUID) error {
+	result := s.db.Model(&models.WellbeingAlert{}).
+		Where("id = ? AND user_id = ? AND dismissed_at IS NULL", alertID, userID).
+		Update("dismissed_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAlertNotFound
+	}
+	return nil
+}
+
+// sustainedLow checks for LowMoodDays consecutive daily check-ins below the threshold
+func (s *WellbeingService) sustainedLow(recent []models.FeelCheck, alert *models.WellbeingAlert) bool {
+	days := s.cfg.LowMoodDays
+	if days < 1 || len(recent) < days {
+		return false
+	}
+	for i := 0; i < days; i++ {
+		if recent[i].FeelScore >= s.cfg.LowMoodThreshold {
+			return false
+		}
+		if i > 0 && recent[i-1].CheckDate.Sub(recent[i].CheckDate) != 24*time.Hour {
+			return false
+		}
+	}
+
+	alert.Kind = models.AlertSustainedLow
+	alert.Value = float64(days)
+	return true
+}
+
+// sharpDrop compares the latest score with the average of the ones before it
+func (s *WellbeingService) sharpDrop(recent []models.FeelCheck, alert *models.WellbeingAlert) bool {
+	if len(recent) < wellbeingMinHistory {
+		return false
+	}
+	sum := 0
+	for _, c := range recent[1:] {
+		sum += c.FeelScore
+	}
+	drop := float64(sum)/float64(len(recent)-1) - float64(recent[0].FeelScore)
+	if drop < float64(s.cfg.MoodDropPoints) {
+		return false
+	}
+
+	alert.Kind = models.AlertSharpDrop
+	alert.Value = round1(drop)
+	return true
+}
+
+// highVolatility checks the standard deviation of the recent window
+func (s *WellbeingService) highVolatility(recent []models.FeelCheck, alert *models.WellbeingAlert) bool {
+	window := recent
+	if len(window) > wellbeingWindow {
+		window = window[:wellbeingWindow]
+	}
+	if len(window) < wellbeingMinHistory {
+		return false
+	}
+
+	mean := 0.0
+	for _, c := range window {
+		mean += float64(c.FeelScore)
+	}
+	mean /= float64(len(window))
+
+	variance := 0.0
+	for _, c := range window {
+		variance += (float64(c.FeelScore) - mean) * (float64(c.FeelScore) - mean)
+	}
+	stddev := math.Sqrt(variance / float64(len(window)))
+	if stddev < float64(s.cfg.MoodVolatilityStdDev) {
+		return false
+	}
+
+	alert.Kind = models.AlertHighVolatility
+	alert.Value = round1(stddev)
+	return true
+}
+
+// coolingDown reports whether an alert of this kind, or any alert, was raised too recently
+func (s *WellbeingService) coolingDown(userID uuid.UUID, kind string) (bool, error) {
+	now := time.Now()
+	var count int64
+	err := s.db.Model(&models.WellbeingAlert{}).
+		Where("user_id = ?", userID).
+		Where("(created_at >= ? OR (kind = ? AND created_at >= ?))",
+			now.Add(-wellbeingGlobalQuiet), kind, now.Add(-s.cfg.WellbeingCooldown)).
+		Count(&count).Error
+	return count > 0, err
+}
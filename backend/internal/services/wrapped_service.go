@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/feelsy/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Dec 31 has ended in every time zone by Jan 2 00:00 UTC
+const wrappedSettle = 24 * time.Hour
+
+var (
+	ErrWrappedNotFound    = errors.New("wrapped summary not found")
+	ErrInvalidWrappedYear = errors.New("invalid wrapped year")
+)
+
+type WrappedService struct {
+	db *gorm.DB
+}
+
+func NewWrappedService(db *gorm.DB) *WrappedService {
+	return &WrappedService{db: db}
+}
+
+// RunScheduler generates the previous year's summaries every interval until ctx is done.
+// The year is only picked up once it has ended in every time zone. Only users
+// without an up-to-date summary are processed, so once a year's batch has
+// finished each run is cheap.
+func (s *WrappedService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		year := time.Now().UTC().Add(-wrappedSettle).Year() - 1
+		generated, err := s.GenerateYear(year)
+		if err != nil {
+			log.Printf("Wrapped generation for %d failed: %v", year, err)
+		} else if generated > 0 {
+			log.Printf("Generated %d wrapped summaries for %d", generated, year)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GenerateYear builds and stores summaries in batches for every user who checked
+// in during the year and has no summary at the current version, or whose check-ins
+// for the year changed after the summary was generated
+func (s *WrappedService) GenerateYear(year int) (int, error) {
+	// Widened by a day on each side; exact bounds use the user's time zone
+	from := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+	to := time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+
+	generated := 0
+	var users []models.User
+	result := s.db.Select("id", "timezone").
+		Where("EXISTS (SELECT 1 FROM feel_checks fc WHERE fc.user_id = users.id AND fc.deleted_at IS NULL AND fc.check_date BETWEEN ? AND ?)", from, to).
+		Where(`NOT EXISTS (SELECT 1 FROM wrapped_summaries w WHERE w.user_id = users.id AND w.year = ? AND w.version >= ?
+			AND w.generated_at >= (SELECT MAX(GREATEST(fc.updated_at, fc.deleted_at)) FROM feel_checks fc
+				WHERE fc.user_id = users.id AND fc.check_date BETWEEN ? AND ?))`, year, models.WrappedVersion, from, to).
+		FindInBatches(&users, 100, func(tx *gorm.DB, batch int) error {
+			for i := range users {
+				doc, err := s.BuildDocument(&users[i], year)
+				if err != nil {
+					log.Printf("Failed to build wrapped %d for user %s: %v", year, users[i].ID, err)
+					continue
+				}
+				if err := s.save(users[i].ID, doc); err != nil {
+					return err
+				}
+				generated++
+			}
+			return nil
+		})
+
+	return generated, result.Error
+}
+
+// GetWrapped returns the user's stored summary for a year, or the latest one when year is 0
+func (s *WrappedService) GetWrapped(userID uuid.UUID, year int) (*models.WrappedSummary, error) {
+	query := s.db.Where("user_id = ?", userID)
+	if year != 0 {
+		query = query.Where("year = ?", year)
+	}
+
+	var summary models.WrappedSummary
+	if err := query.Order("year DESC").First(&summary).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWrappedNotFound
+		}
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// PreviewWrapped regenerates a user's summary on demand without storing it
+func (s *WrappedService) PreviewWrapped(userID uuid.UUID, year int) (*models.WrappedDocument, error) {
+	if year < 2000 || year > time.Now().UTC().Year() {
+		return nil, ErrInvalidWrappedYear
+	}
+
+	var user models.User
+	if err := s.db.Select("id", "timezone").First(&user, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return s.BuildDocument(&user, year)
+}
+
+// BuildDocument computes the year-in-review for a user, bucketing check-ins
+// by the local day they were made on
+func (s *WrappedService) BuildDocument(user *models.User, year int) (*models.WrappedDocument, error) {
+	loc := user.Location()
+	tz := loc.String()
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC)
+	from, to := localStart(start, loc), localEnd(end, loc)
+	inYear := "(created_at AT TIME ZONE ?)::date BETWEEN ? AND ?"
+
+	doc := &models.WrappedDocument{
+		Version:      models.WrappedVersion,
+		Year:         year,
+		Timezone:     tz,
+		Months:       make([]models.WrappedMonth, 12),
+		BadgesEarned: []string{},
+		GeneratedAt:  time.Now(),
+	}
+	for i := range doc.Months {
+		doc.Months[i].Month = i + 1
+	}
+
+	// Mood of each month
+	var months []struct {
+		Month    int
+		CheckIns int
+		AvgScore float64
+	}
+	err := s.db.Model(&models.FeelCheck{}).
+		Select("EXTRACT(MONTH FROM (created_at AT TIME ZONE ?))::int AS month, COUNT(*) AS check_ins, AVG(feel_score) AS avg_score", tz).
+		Where("user_id = ? AND "+inYear, user.ID, tz, start, end).
+		Group("month").
+		Scan(&months).Error
+	if err != nil {
+		return nil, err
+	}
+
+	formula := models.ActiveScoreFormula()
+	for _, m := range months {
+		if m.Month < 1 || m.Month > 12 {
+			continue
+		}
+		avg := round1(m.AvgScore)
+		band := formula.Band(int(math.Round(m.AvgScore)))
+		month := &doc.Months[m.Month-1]
+		month.CheckIns = m.CheckIns
+		month.AvgScore = &avg
+		month.ColorHex = band.Color
+		month.Label = band.Label
+		doc.TotalCheckIns += m.CheckIns
+	}
+
+	var monthEmojis []struct {
+		Month     int
+		MoodEmoji string
+	}
+	err = s.db.Raw(`
+		SELECT DISTINCT ON (month) month, mood_emoji
+		FROM (
+			SELECT EXTRACT(MONTH FROM (created_at AT TIME ZONE ?))::int AS month, mood_emoji, COUNT(*) AS uses
+			FROM feel_checks
+			WHERE user_id = ? AND deleted_at IS NULL AND mood_emoji <> ''
+				AND (created_at AT TIME ZONE ?)::date BETWEEN ? AND ?
+			GROUP BY month, mood_emoji
+		) e
+		ORDER BY month, uses DESC, mood_emoji`, tz, user.ID, tz, start, end).
+		Scan(&monthEmojis).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range monthEmojis {
+		if e.Month >= 1 && e.Month <= 12 {
+			doc.Months[e.Month-1].MoodEmoji = e.MoodEmoji
+		}
+	}
+
+	// Most frequent emoji
+	var emojis []models.WrappedEmoji
+	err = s.db.Model(&models.FeelCheck{}).
+		Select("mood_emoji AS emoji, COUNT(*) AS count").
+		Where("user_id = ? AND mood_emoji <> '' AND "+inYear, user.ID, tz, start, end).
+		Group("mood_emoji").
+		Order("count DESC, mood_emoji").
+		Limit(1).
+		Scan(&emojis).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(emojis) > 0 {
+		doc.TopEmoji = &emojis[0]
+	}
+
+	// Longest streak (gaps-and-islands over local check-in days)
+	var streaks []struct {
+		StartDate time.Time
+		EndDate   time.Time
+		Days      int
+	}
+	err = s.db.Raw(`
+		WITH days AS (
+			SELECT DISTINCT (created_at AT TIME ZONE ?)::date AS day
+			FROM feel_checks
+			WHERE user_id = ? AND deleted_at IS NULL
+				AND (created_at AT TIME ZONE ?)::date BETWEEN ? AND ?
+		), islands AS (
+			SELECT day, day - (ROW_NUMBER() OVER (ORDER BY day))::int AS grp
+			FROM days
+		)
+		SELECT MIN(day) AS start_date, MAX(day) AS end_date, COUNT(*) AS days
+		FROM islands
+		GROUP BY grp
+		ORDER BY days DESC, end_date DESC
+		LIMIT 1`, tz, user.ID, tz, start, end).
+		Scan(&streaks).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(streaks) > 0 {
+		doc.LongestStreak = models.WrappedStreak{
+			Days:      streaks[0].Days,
+			StartDate: streaks[0].StartDate.Format("2006-01-02"),
+			EndDate:   streaks[0].EndDate.Format("2006-01-02"),
+		}
+	}
+
+	// Happiest weekday
+	var weekdays []struct {
+		Dow      int
+		AvgScore float64
+		CheckIns int
+	}
+	err = s.db.Model(&models.FeelCheck{}).
+		Select("EXTRACT(DOW FROM (created_at AT TIME ZONE ?))::int AS dow, AVG(feel_score) AS avg_score, COUNT(*) AS check_ins", tz).
+		Where("user_id = ? AND "+inYear, user.ID, tz, start, end).
+		Group("dow").
+		Order("avg_score DESC, check_ins DESC, dow").
+		Limit(1).
+		Scan(&weekdays).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(weekdays) > 0 {
+		doc.HappiestWeekday = &models.WrappedWeekday{
+			Weekday:  time.Weekday(weekdays[0].Dow).String(),
+			AvgScore: round1(weekdays[0].AvgScore),
+			CheckIns: weekdays[0].CheckIns,
+		}
+	}
+
+	// Most supportive friend, by vibes sent and received
+	var friends []models.WrappedFriend
+	err = s.db.Raw(`
+		SELECT v.other_id AS user_id, u.email,
+			SUM(v.sent) AS vibes_sent, SUM(v.received) AS vibes_received
+		FROM (
+			SELECT CASE WHEN sender_id = ? THEN receiver_id ELSE sender_id END AS other_id,
+				(sender_id = ?)::int AS sent, (receiver_id = ?)::int AS received
+			FROM good_vibes
+			WHERE (sender_id = ? OR receiver_id = ?) AND deleted_at IS NULL
+				AND created_at >= ? AND created_at < ?
+		) v
+		JOIN users u ON u.id = v.other_id AND u.deleted_at IS NULL
+		GROUP BY v.other_id, u.email
+		ORDER BY SUM(v.sent) + SUM(v.received) DESC, SUM(v.received) DESC, u.email
+		LIMIT 1`, user.ID, user.ID, user.ID, user.ID, user.ID, from, to).
+		Scan(&friends).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(friends) > 0 {
+		doc.SupportiveFriend = &friends[0]
+	}
+
+	// Badges earned
+	err = s.db.Model(&models.BadgeUnlock{}).
+		Where("user_id = ? AND unlocked_at >= ? AND unlocked_at < ?", user.ID, from, to).
+		Order("unlocked_at").
+		Pluck("badge", &doc.BadgesEarned).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// save upserts the summary for the document's year
+func (s *WrappedService) save(userID uuid.UUID, doc *models.WrappedDocument) error {
+	summary := models.WrappedSummary{
+		UserID:      userID,
+		Year:        doc.Year,
+		Version:     doc.Version,
+		Document:    *doc,
+		GeneratedAt: doc.GeneratedAt,
+	}
+	return s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "year"}},
+		DoUpdates: clause.AssignmentColumns([]string{"version", "document", "generated_at", "updated_at"}),
+	}).Create(&summary).Error
+}
@@ -1,13 +1,18 @@
-import React from 'react';
+import React, { useEffect } from 'react';
 import { View, ActivityIndicator } from 'react-native';
 import { Redirect, Tabs } from 'expo-router';
 import { Ionicons } from '@expo/vector-icons';
 import { useAuth } from '../../contexts/AuthContext';
 import { hapticSelection } from '../../lib/haptics';
+import { loadScoreScale } from '../../lib/scale';
 
 export default function ProtectedLayout() {
   const { isAuthenticated, isLoading } = useAuth();
 
+  useEffect(() => {
+    if (isAuthenticated) loadScoreScale();
+  }, [isAuthenticated]);
+
   if (isLoading) {
     return (
       <View className="flex-1 items-center justify-center bg-white">
@@ -2,31 +2,35 @@ import React, { useState, useEffect, useCallback } from 'react';
 import { View, Text, FlatList, RefreshControl } from 'react-native';
 import { SafeAreaView } from 'react-native-safe-area-context';
 import api from '../../lib/api';
-import { FeelCheck, getColorForScore, getFeelLabel } from '../../types/feel';
+import { FeelCheck, FeelHistoryPage, getColorForScore, getFeelLabel } from '../../types/feel';
 
 export default function HistoryScreen() {
   const [checks, setChecks] = useState<FeelCheck[]>([]);
   const [isLoading, setIsLoading] = useState(true);
   const [isRefreshing, setIsRefreshing] = useState(false);
   const [total, setTotal] = useState(0);
-  const [offset, setOffset] = useState(0);
+  const [cursor, setCursor] = useState<string | null>(null);
+  const [hasMore, setHasMore] = useState(true);
   const limit = 20;
 
   useEffect(() => {
-    loadHistory();
+    loadHistory(true);
   }, []);
 
   const loadHistory = async (refresh = false) => {
     try {
-      const newOffset = refresh ? 0 : offset;
-      const res = await api.get(`/feels/history?limit=${limit}&offset=${newOffset}`);
+      const params = new URLSearchParams({ limit: String(limit) });
+      if (!refresh && cursor) params.set('cursor', cursor);
+      const res = await api.get<FeelHistoryPage>(`/feels/history?${params.toString()}`);
       if (refresh) {
         setChecks(res.data.data || []);
+        const stats = await api.get('/feels/stats');
+        setTotal(stats.data.total_check_ins || 0);
       } else {
         setChecks(prev => [...prev, ...(res.data.data || [])]);
       }
-      setTotal(res.data.total || 0);
-      setOffset(newOffset + limit);
+      setCursor(res.data.next_cursor || null);
+      setHasMore(res.data.has_more);
     } catch (error) {
       console.log('Error loading history:', error);
     } finally {
@@ -37,7 +41,7 @@ export default function HistoryScreen() {
 
   const onRefresh = useCallback(() => {
     setIsRefreshing(true);
-    setOffset(0);
+    setCursor(null);
     loadHistory(true);
   }, []);
 
@@ -105,7 +109,7 @@ export default function HistoryScreen() {
           <RefreshControl refreshing={isRefreshing} onRefresh={onRefresh} />
         }
         onEndReached={() => {
-          if (checks.length < total) loadHistory();
+          if (hasMore && cursor) loadHistory();
         }}
         onEndReachedThreshold={0.5}
         ListEmptyComponent={
@@ -0,0 +1,26 @@
+import * as SecureStore from 'expo-secure-store';
+import api from './api';
+import { ScoreBand, setScoreBands } from '../types/feel';
+
+const SCORE_BANDS_KEY = 'score_bands';
+
+// Applies the cached score bands right away, then refreshes them from the API
+// so colors and labels always match the backend's scale
+export const loadScoreScale = async (): Promise<void> => {
+  try {
+    const cached = await SecureStore.getItemAsync(SCORE_BANDS_KEY);
+    if (cached) setScoreBands(JSON.parse(cached));
+  } catch (error) {
+    console.log('Error reading cached score scale:', error);
+  }
+
+  try {
+    const res = await api.get<{ bands: ScoreBand[] }>('/feels/scale');
+    if (res.data?.bands?.length) {
+      setScoreBands(res.data.bands);
+      await SecureStore.setItemAsync(SCORE_BANDS_KEY, JSON.stringify(res.data.bands));
+    }
+  } catch (error) {
+    console.log('Error loading score scale:', error);
+  }
+};
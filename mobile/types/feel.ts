@@ -9,6 +9,13 @@ export interface FeelCheck {
   check_date: string;
 }
 
+export interface FeelHistoryPage {
+  data: FeelCheck[];
+  next_cursor?: string;
+  has_more: boolean;
+  limit: number;
+}
+
 export interface FeelStats {
   current_streak: number;
   longest_streak: number;
@@ -46,18 +53,33 @@ export const VIBE_EMOJIS: Record<VibeType, string> = {
 
 export const MOOD_EMOJIS = ['😊', '😌', '😔', '😢', '😤', '😴', '🤩', '😎', '🥺', '😅'];
 
-export const getColorForScore = (score: number): string => {
-  if (score >= 80) return '#22c55e'; // Green - great
-  if (score >= 60) return '#84cc16'; // Lime - good
-  if (score >= 40) return '#eab308'; // Yellow - okay
-  if (score >= 20) return '#f97316'; // Orange - not great
-  return '#ef4444'; // Red - struggling
-};
+export interface ScoreBand {
+  min: number;
+  max: number;
+  color: string;
+  label: string;
+}
+
+// Mirrors the backend's default bands; replaced by GET /feels/scale once loaded
+export const DEFAULT_SCORE_BANDS: ScoreBand[] = [
+  { min: 90, max: 100, color: '#22c55e', label: 'Amazing' },
+  { min: 75, max: 89, color: '#84cc16', label: 'Great' },
+  { min: 60, max: 74, color: '#eab308', label: 'Good' },
+  { min: 45, max: 59, color: '#f97316', label: 'Okay' },
+  { min: 30, max: 44, color: '#ef4444', label: 'Not great' },
+  { min: 1, max: 29, color: '#8b5cf6', label: 'Low' },
+];
 
-export const getFeelLabel = (score: number): string => {
-  if (score >= 80) return 'Thriving';
-  if (score >= 60) return 'Good';
-  if (score >= 40) return 'Okay';
-  if (score >= 20) return 'Low';
-  return 'Struggling';
+let scoreBands: ScoreBand[] = DEFAULT_SCORE_BANDS;
+
+// Bands are ordered from highest to lowest, as the API returns them
+export const setScoreBands = (bands: ScoreBand[]) => {
+  if (bands.length > 0) scoreBands = bands;
 };
+
+const bandForScore = (score: number): ScoreBand =>
+  scoreBands.find((band) => score >= band.min) ?? scoreBands[scoreBands.length - 1];
+
+export const getColorForScore = (score: number): string => bandForScore(score).color;
+
+export const getFeelLabel = (score: number): string => bandForScore(score).label;